@@ -0,0 +1,328 @@
+/*
+Copyright © 2023 Aric Hansen <aric.p.hansen@gmail.com>
+*/
+package cmd
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"strconv"
+	"strings"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goitalic"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+// supported values for the render --size flag
+const (
+	cardSquare = "square"
+	cardBanner = "banner"
+	cardStory  = "story"
+)
+
+// supported values for the render --theme flag
+const (
+	cardDark      = "dark"
+	cardLight     = "light"
+	cardCharacter = "character"
+)
+
+type cardSize struct {
+	width  int
+	height int
+}
+
+// smallest font size of the quote on a card, to keep it readable
+const minCardFontSize = 12
+
+var cardSizes = map[string]cardSize{
+	cardSquare: {1080, 1080},
+	cardBanner: {1500, 500},
+	cardStory:  {1080, 1920},
+}
+
+// RGB values of the named colors from ansiColors, for drawing cards
+var cardColors = map[string]color.RGBA{
+	"black":   {0x00, 0x00, 0x00, 0xff},
+	"red":     {0xe0, 0x44, 0x3e, 0xff},
+	"green":   {0x3f, 0xae, 0x49, 0xff},
+	"yellow":  {0xf2, 0xc1, 0x2e, 0xff},
+	"blue":    {0x3b, 0x7d, 0xd8, 0xff},
+	"magenta": {0xc8, 0x4f, 0xc8, 0xff},
+	"cyan":    {0x3c, 0xc6, 0xd6, 0xff},
+	"white":   {0xff, 0xff, 0xff, 0xff},
+	"grey":    {0x9a, 0x9a, 0x9a, 0xff},
+	"orange":  {0xff, 0x88, 0x00, 0xff},
+	"purple":  {0x8e, 0x5b, 0xd8, 0xff},
+	"pink":    {0xff, 0x6f, 0xb5, 0xff},
+}
+
+var (
+	cardDarkBackground  = color.RGBA{0x1d, 0x1f, 0x2b, 0xff}
+	cardLightBackground = color.RGBA{0xf4, 0xef, 0xe4, 0xff}
+)
+
+// getCardColor returns the RGB value of a named or "#rrggbb" color
+func getCardColor(name string) (color.RGBA, bool) {
+	if c, ok := cardColors[strings.ToLower(name)]; ok {
+		return c, true
+	}
+
+	if len(name) == 7 && strings.HasPrefix(name, "#") {
+		rgb, err := strconv.ParseUint(name[1:], 16, 32)
+		if err == nil {
+			return color.RGBA{uint8(rgb >> 16), uint8(rgb >> 8 & 0xff), uint8(rgb & 0xff), 0xff}, true
+		}
+	}
+
+	return color.RGBA{}, false
+}
+
+// cardPalette holds the colors of a card, picked from its background
+type cardPalette struct {
+	background color.RGBA
+	text       color.RGBA
+	muted      color.RGBA // stage directions and the footer
+}
+
+func newCardPalette(background color.RGBA) cardPalette {
+	luminance := (299*int(background.R) + 587*int(background.G) + 114*int(background.B)) / 1000
+	if luminance > 140 {
+		return cardPalette{background, color.RGBA{0x22, 0x22, 0x22, 0xff}, color.RGBA{0x66, 0x66, 0x66, 0xff}}
+	}
+
+	return cardPalette{background, color.RGBA{0xff, 0xff, 0xff, 0xff}, color.RGBA{0xb0, 0xb0, 0xb8, 0xff}}
+}
+
+// getCardPalette picks the colors of a card from --theme and --background
+func getCardPalette(q Quote, t Theme) cardPalette {
+	if c, ok := getCardColor(RenderBackground); ok {
+		return newCardPalette(c)
+	}
+
+	switch RenderTheme {
+	case cardLight:
+		return newCardPalette(cardLightBackground)
+	case cardCharacter:
+		for _, speaker := range q.speakers {
+			if c, ok := getCardColor(t.Characters[speaker]); ok {
+				// a dark shade of the speaker's color keeps the text readable
+				return newCardPalette(color.RGBA{c.R / 4, c.G / 4, c.B / 4, 0xff})
+			}
+		}
+	}
+
+	return newCardPalette(cardDarkBackground)
+}
+
+// cardFonts holds the faces of the embedded Go fonts at the sizes used on a card
+type cardFonts struct {
+	regular font.Face
+	italic  font.Face
+	bold    font.Face // speaker names
+	small   font.Face // footer
+	size    int
+}
+
+func newCardFonts(size int, footerSize int) (cardFonts, error) {
+	faces := []font.Face{}
+	for _, f := range []struct {
+		ttf  []byte
+		size int
+	}{{goregular.TTF, size}, {goitalic.TTF, size}, {gobold.TTF, size * 3 / 5}, {goregular.TTF, footerSize}} {
+		parsed, err := opentype.Parse(f.ttf)
+		if err != nil {
+			return cardFonts{}, err
+		}
+		face, err := opentype.NewFace(parsed, &opentype.FaceOptions{Size: float64(f.size), DPI: 72, Hinting: font.HintingFull})
+		if err != nil {
+			return cardFonts{}, err
+		}
+		faces = append(faces, face)
+	}
+
+	return cardFonts{regular: faces[0], italic: faces[1], bold: faces[2], small: faces[3], size: size}, nil
+}
+
+// cardWord is a word of a quote drawn with a face and color (e.g. italics for stage directions)
+type cardWord struct {
+	text  string
+	face  font.Face
+	color color.Color
+}
+
+// cardLine is a line of text laid out on a card
+type cardLine struct {
+	words  []cardWord
+	height int
+}
+
+// layoutCardLines breaks a quote into lines of at most width pixels, with the speaker's name above each of their turns
+func layoutCardLines(q Quote, fonts cardFonts, width int, palette cardPalette, t Theme) []cardLine {
+	lines := []cardLine{}
+	lineHeight := fonts.size * 13 / 10
+	space := font.MeasureString(fonts.regular, " ").Ceil()
+
+	previousSpeaker := ""
+	for i, text := range q.lines {
+		speaker := ""
+		if i < len(q.speakers) {
+			speaker = q.speakers[i]
+		}
+		text = strings.TrimSpace(text)
+
+		if speaker != "" {
+			name := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(speakerPrefix.FindString(text)), ":"))
+			text = strings.TrimSpace(text[len(speakerPrefix.FindString(text)):])
+			if speaker != previousSpeaker {
+				nameColor, ok := getCardColor(t.Characters[speaker])
+				if !ok {
+					nameColor = palette.text
+				}
+				if len(lines) > 0 {
+					lines = append(lines, cardLine{height: fonts.size / 2}) // gap between speaker turns
+				}
+				lines = append(lines, cardLine{
+					words:  []cardWord{{strings.ToUpper(name), fonts.bold, nameColor}},
+					height: fonts.size,
+				})
+			}
+		} else if len(lines) > 0 {
+			lines = append(lines, cardLine{height: fonts.size / 2})
+		}
+		previousSpeaker = speaker
+
+		// stage directions are drawn in italics, in the muted color
+		words := []cardWord{}
+		inDirection := false
+		for _, word := range strings.Fields(text) {
+			if strings.HasPrefix(word, "[") {
+				inDirection = true
+			}
+			if inDirection {
+				words = append(words, cardWord{word, fonts.italic, palette.muted})
+			} else {
+				words = append(words, cardWord{word, fonts.regular, palette.text})
+			}
+			if strings.HasSuffix(word, "]") {
+				inDirection = false
+			}
+		}
+
+		line := cardLine{height: lineHeight}
+		lineWidth := 0
+		for _, word := range words {
+			wordWidth := font.MeasureString(word.face, word.text).Ceil()
+			if len(line.words) > 0 && lineWidth+space+wordWidth > width {
+				lines = append(lines, line)
+				line = cardLine{height: lineHeight}
+				lineWidth = 0
+			}
+			if len(line.words) > 0 {
+				lineWidth += space
+			}
+			line.words = append(line.words, word)
+			lineWidth += wordWidth
+		}
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+// drawCard draws a quote card: the quote centered vertically next to an accent bar in the first speaker's color,
+// and the episode in the footer. The font size is reduced until the quote fits, down to minCardFontSize.
+func drawCard(q Quote, size cardSize, t Theme) (*image.RGBA, error) {
+	img := image.NewRGBA(image.Rect(0, 0, size.width, size.height))
+	palette := getCardPalette(q, t)
+	draw.Draw(img, img.Bounds(), &image.Uniform{palette.background}, image.Point{}, draw.Src)
+
+	short := size.width
+	if size.height < short {
+		short = size.height
+	}
+	margin := short / 12
+	footerSize := short / 36
+	if footerSize < 14 {
+		footerSize = 14
+	}
+	footerHeight := footerSize * 3
+	barWidth := margin / 6
+	textLeft := margin + barWidth*3
+	textWidth := size.width - textLeft - margin
+	textHeight := size.height - 2*margin - footerHeight
+
+	var fonts cardFonts
+	var lines []cardLine
+	blockHeight := 0
+	for fontSize := short / 12; ; fontSize -= 2 {
+		var err error
+		fonts, err = newCardFonts(fontSize, footerSize)
+		if err != nil {
+			return nil, err
+		}
+		lines = layoutCardLines(q, fonts, textWidth, palette, t)
+		blockHeight = 0
+		for _, line := range lines {
+			blockHeight += line.height
+		}
+		if blockHeight <= textHeight {
+			break
+		}
+		if fontSize-2 < minCardFontSize {
+			return nil, fmt.Errorf("The quote is too long to fit on a %dx%d card. Please try a larger --size.", size.width, size.height)
+		}
+	}
+
+	// accent bar
+	accent := palette.text
+	for _, speaker := range q.speakers {
+		if c, ok := getCardColor(t.Characters[speaker]); ok {
+			accent = c
+			break
+		}
+	}
+	top := margin + (textHeight-blockHeight)/2
+	if top < margin {
+		top = margin
+	}
+	bar := image.Rect(margin, top, margin+barWidth, top+blockHeight)
+	draw.Draw(img, bar, &image.Uniform{accent}, image.Point{}, draw.Src)
+
+	// quote
+	space := font.MeasureString(fonts.regular, " ")
+	y := top
+	for _, line := range lines {
+		y += line.height
+		x := fixed.I(textLeft)
+		for _, word := range line.words {
+			d := font.Drawer{Dst: img, Src: &image.Uniform{word.color}, Face: word.face, Dot: fixed.Point26_6{X: x, Y: fixed.I(y - line.height/5)}}
+			d.DrawString(word.text)
+			x = d.Dot.X + space
+		}
+	}
+
+	// footer
+	footer := []string{
+		q.episode,
+		fmt.Sprintf("Futurama · Season %d · %s", q.season, q.id),
+	}
+	y = size.height - margin - footerHeight + footerSize
+	for i, text := range footer {
+		c := palette.muted
+		if i == 0 {
+			c = palette.text
+		}
+		d := font.Drawer{Dst: img, Src: &image.Uniform{c}, Face: fonts.small, Dot: fixed.P(textLeft, y)}
+		d.DrawString(text)
+		y += footerSize * 3 / 2
+	}
+
+	return img, nil
+}
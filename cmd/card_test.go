@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDrawCardLongQuote(t *testing.T) {
+	q := Quote{season: 1, episode: "Space Pilot 3000", id: "s01e01-00000000", lines: []string{"Fry: Yes."}, speakers: []string{"Fry"}}
+	if _, err := drawCard(q, cardSizes[cardBanner], getDefaultTheme()); err != nil {
+		t.Fatal(err)
+	}
+
+	q.lines = []string{"Fry: " + strings.Repeat("Space. It seems to go on and on forever. ", 300)}
+	_, err := drawCard(q, cardSizes[cardBanner], getDefaultTheme())
+	if err == nil || !strings.Contains(err.Error(), "too long") {
+		t.Errorf("got error %v, want the quote to be too long", err)
+	}
+}
@@ -0,0 +1,188 @@
+/*
+Copyright © 2023 Aric Hansen <aric.p.hansen@gmail.com>
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+var ColorMode string
+
+// supported values for the --color flag
+const (
+	colorAuto   = "auto"   // color when stdout is a terminal and NO_COLOR is not set (default)
+	colorAlways = "always" // always color
+	colorNever  = "never"  // never color
+)
+
+// ANSI escape codes for the named colors available to themes and templates
+var ansiColors = map[string]string{
+	"black":   "30",
+	"red":     "31",
+	"green":   "32",
+	"yellow":  "33",
+	"blue":    "34",
+	"magenta": "35",
+	"cyan":    "36",
+	"white":   "37",
+	"grey":    "90",
+	"orange":  "38;5;208",
+	"purple":  "38;5;135",
+	"pink":    "38;5;205",
+	"bold":    "1",
+	"italic":  "3",
+}
+
+// Theme picks the colors of quote output. Colors are names from ansiColors or "#rrggbb" hex values.
+type Theme struct {
+	Characters     map[string]string `json:"characters,omitempty"`
+	Header         string            `json:"header,omitempty"`
+	StageDirection string            `json:"stageDirection,omitempty"`
+	Highlight      string            `json:"highlight,omitempty"`
+}
+
+func getDefaultTheme() Theme {
+	return Theme{
+		Characters: map[string]string{
+			"Fry":              "orange",
+			"Leela":            "purple",
+			"Bender":           "grey",
+			"Prof. Farnsworth": "cyan",
+			"Zoidberg":         "red",
+			"Hermes":           "green",
+			"Amy":              "pink",
+			"Zapp Brannigan":   "yellow",
+		},
+		Header:         "bold",
+		StageDirection: "italic",
+		Highlight:      "bold",
+	}
+}
+
+// colors used for this run, set by validateColor
+var useColors bool
+var theme = getDefaultTheme()
+
+// validateColor decides whether to color output, and loads the user's theme if so
+func validateColor() error {
+	switch ColorMode {
+	case colorAlways:
+		useColors = true
+	case colorNever:
+		useColors = false
+	case colorAuto:
+		useColors = os.Getenv("NO_COLOR") == "" && term.IsTerminal(int(os.Stdout.Fd()))
+	default:
+		return errors.New("Invalid color mode. Please select one of: auto, always, never.")
+	}
+
+	if !useColors {
+		return nil
+	}
+
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	theme = mergeTheme(getDefaultTheme(), config.Theme)
+
+	return validateTheme(theme)
+}
+
+// mergeTheme applies the user-defined colors on top of the default theme
+func mergeTheme(base Theme, user Theme) Theme {
+	for name, color := range user.Characters {
+		if normalized, err := getSupportedCharacter(name); err == nil {
+			name = normalized
+		}
+		base.Characters[name] = color
+	}
+	if user.Header != "" {
+		base.Header = user.Header
+	}
+	if user.StageDirection != "" {
+		base.StageDirection = user.StageDirection
+	}
+	if user.Highlight != "" {
+		base.Highlight = user.Highlight
+	}
+
+	return base
+}
+
+func validateTheme(t Theme) error {
+	colors := []string{t.Header, t.StageDirection, t.Highlight}
+	for _, color := range t.Characters {
+		colors = append(colors, color)
+	}
+
+	for _, color := range colors {
+		if _, ok := getColorCode(color); !ok {
+			return errors.New("Invalid color in theme: " + color + ". Please use a color name (e.g. 'orange') or a hex value (e.g. '#ff8800').")
+		}
+	}
+
+	return nil
+}
+
+// getColorCode returns the ANSI escape code of a named or "#rrggbb" color
+func getColorCode(name string) (string, bool) {
+	if code, ok := ansiColors[strings.ToLower(name)]; ok {
+		return code, true
+	}
+
+	if len(name) == 7 && strings.HasPrefix(name, "#") {
+		rgb, err := strconv.ParseUint(name[1:], 16, 32)
+		if err == nil {
+			return fmt.Sprintf("38;2;%d;%d;%d", rgb>>16, rgb>>8&0xff, rgb&0xff), true
+		}
+	}
+
+	return "", false
+}
+
+// colorize wraps text in the ANSI escape code of a color.
+// Text is left unchanged if colors are disabled or the color is unknown.
+func colorize(name string, text string) string {
+	code, ok := getColorCode(name)
+	if !useColors || !ok || text == "" {
+		return text
+	}
+
+	return "\x1b[" + code + "m" + text + "\x1b[0m"
+}
+
+// stageDirection matches bracketed stage directions such as "[Fry walks in]"
+var stageDirection = regexp.MustCompile(`\[[^\]]*\]`)
+
+// colorLine colors the speaker's name and the stage directions of a quote line
+func colorLine(line string, speaker string) string {
+	if !useColors {
+		return line
+	}
+
+	prefix := ""
+	if speaker != "" {
+		prefix = speakerPrefix.FindString(line)
+		line = line[len(prefix):]
+	}
+
+	line = stageDirection.ReplaceAllStringFunc(line, func(direction string) string {
+		return colorize(theme.StageDirection, direction)
+	})
+
+	return colorize(theme.Characters[speaker], prefix) + line
+}
+
+// printField prints a "Label: value" line with a colored label
+func printField(label string, value any) {
+	fmt.Println(colorize(theme.Header, label+":"), value)
+}
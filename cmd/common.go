@@ -22,6 +22,10 @@ type Episode struct {
 type Quote struct {
 	characters []string
 	lines      []string
+	speakers   []string // speaker of each line, empty for stage directions
+	season     int      // season number the quote was parsed from
+	episode    string   // episode name as it appears on WikiQuote
+	id         string   // stable ID, see getQuoteID
 }
 
 type possibleNames struct {
@@ -231,8 +235,8 @@ func validateEpisodeName(episode string) (error, int) {
 	return errors.New("Invalid episode name. Please use the `futurama get episodes` command for assistance."), 0
 }
 
-func normalizeName(character string) string {
-
+// getPossibleNames lists the names used on WikiQuote for each supported character
+func getPossibleNames() []possibleNames {
 	names := []possibleNames{
 		{
 			normalizedName: "Fry",
@@ -323,7 +327,11 @@ func normalizeName(character string) string {
 		},
 	}
 
-	for _, n := range names {
+	return names
+}
+
+func normalizeName(character string) string {
+	for _, n := range getPossibleNames() {
 		for _, a := range n.alternatives {
 			if character == a {
 				return n.normalizedName
@@ -0,0 +1,21 @@
+/*
+Copyright © 2023 Aric Hansen <aric.p.hansen@gmail.com>
+*/
+package cmd
+
+// Config is the JSON document stored in the futurama config file (e.g. ~/.config/futurama/config.json)
+type Config struct {
+	Theme Theme `json:"theme"`
+}
+
+func loadConfig() (Config, error) {
+	config := Config{}
+
+	path, err := getDataPath("config.json")
+	if err != nil {
+		return config, err
+	}
+
+	err = readJSONFile(path, &config)
+	return config, err
+}
@@ -0,0 +1,129 @@
+/*
+Copyright © 2023 Aric Hansen <aric.p.hansen@gmail.com>
+*/
+package cmd
+
+import (
+	"hash/fnv"
+	"io"
+	"strings"
+)
+
+// parsed WikiQuote pages by title and section, so each page is only retrieved once per run
+var pageCache = map[string]Season{}
+
+// getPageQuotes retrieves and parses a WikiQuote page (or only one of its sections), or returns it from the cache
+func getPageQuotes(title string, section string, parse func(r io.Reader) (Season, error)) (Season, error) {
+	key := title + "#" + section
+	if season, ok := pageCache[key]; ok {
+		return season, nil
+	}
+
+	var page wikiPage
+	var err error
+	if section == "" {
+		page, err = wikiquote.getPage(title)
+	} else {
+		page, err = wikiquote.getSection(title, section)
+	}
+	if err != nil {
+		return Season{}, err
+	}
+
+	season, err := parse(strings.NewReader(page.html))
+	if err != nil {
+		return Season{}, err
+	}
+	pageCache[key] = season
+	return season, nil
+}
+
+// getFilmQuotes retrieves the quotes of a Season 5 film, which each have their own WikiQuote page
+func getFilmQuotes(film string) (Season, error) {
+	if dbSeasons != nil {
+		return getDBFilmQuotes(film), nil
+	}
+
+	return getPageQuotes(getFilmTitle(film), "Dialogue", func(r io.Reader) (Season, error) {
+		return getSeasonFiveQuotes(r, 5, film)
+	})
+}
+
+// getSeasonQuotesByNumber retrieves every quote from a season, from WikiQuote or the --db corpus.
+// Season 5 is split across one WikiQuote page per film, so each page is fetched in turn.
+func getSeasonQuotesByNumber(seasonNumber int) (Season, error) {
+	if dbSeasons != nil {
+		return dbSeasons[seasonNumber], nil
+	}
+
+	if seasonNumber == 5 {
+		films := getSeries()[seasonNumber-1]
+		season := Season{name: films.name}
+		for _, film := range films.episodes {
+			filmSeason, err := getFilmQuotes(film)
+			if err != nil {
+				return season, err
+			}
+			season.episodes = append(season.episodes, filmSeason.episodes...)
+		}
+		return season, nil
+	}
+
+	return getPageQuotes(getSeasonTitle(seasonNumber), "", func(r io.Reader) (Season, error) {
+		return getSeasonQuotes(r, seasonNumber)
+	})
+}
+
+// getSeriesQuotes retrieves every quote from every season of the series
+func getSeriesQuotes() ([]Season, error) {
+	seasons := []Season{}
+	for i := range getSeries() {
+		season, err := getSeasonQuotesByNumber(i + 1)
+		if err != nil {
+			return seasons, err
+		}
+		seasons = append(seasons, season)
+	}
+
+	return seasons, nil
+}
+
+// getQuotePool flattens seasons into a single ordered list of quotes, skipping empty entries
+func getQuotePool(seasons []Season) []Quote {
+	pool := []Quote{}
+	for _, season := range seasons {
+		for _, ep := range season.episodes {
+			pool = append(pool, getEpisodePool(ep)...)
+		}
+	}
+
+	return pool
+}
+
+// getEpisodePool lists the non-empty quotes of an episode, in page order
+func getEpisodePool(ep Episode) []Quote {
+	pool := []Quote{}
+	for _, q := range ep.quotes {
+		if len(q.lines) > 0 {
+			pool = append(pool, q)
+		}
+	}
+
+	return pool
+}
+
+// corpusVersion hashes the content of a quote pool, so any edit to the
+// WikiQuote pages produces a different version
+func corpusVersion(pool []Quote) uint64 {
+	h := fnv.New64a()
+	for _, q := range pool {
+		h.Write([]byte(q.episode))
+		for _, line := range q.lines {
+			h.Write([]byte{0})
+			h.Write([]byte(line))
+		}
+		h.Write([]byte{1})
+	}
+
+	return h.Sum64()
+}
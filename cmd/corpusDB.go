@@ -0,0 +1,133 @@
+/*
+Copyright © 2023 Aric Hansen <aric.p.hansen@gmail.com>
+*/
+package cmd
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+)
+
+var CorpusDB string
+
+// seasons loaded from the --db corpus, nil when quotes come from WikiQuote
+var dbSeasons map[int]Season
+
+// validateCorpusDB loads the quotes of the --db corpus, if one is provided
+func validateCorpusDB() error {
+	if CorpusDB == "" {
+		return nil
+	}
+
+	seasons, err := loadCorpusDB(CorpusDB)
+	if err != nil {
+		return errors.New("Unable to read the quote database " + CorpusDB + ": " + err.Error())
+	}
+	dbSeasons = seasons
+
+	return nil
+}
+
+// loadCorpusDB reads every quote of a database created with 'export sqlite', in page order.
+// The database is opened read-only.
+func loadCorpusDB(path string) (map[int]Season, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("sqlite", "file:"+path+"?mode=ro")
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	var version int
+	err = db.QueryRow("PRAGMA user_version").Scan(&version)
+	if err != nil {
+		return nil, err
+	}
+	if version != corpusSchemaVersion {
+		return nil, fmt.Errorf("unsupported schema version %d (expected %d), please export it again", version, corpusSchemaVersion)
+	}
+
+	// characters are sorted like the quotes parsed from WikiQuote
+	characters := map[string][]string{}
+	rows, err := db.Query(`
+		SELECT qc.quote_id, c.name
+		FROM quote_characters qc
+		JOIN characters c ON c.id = qc.character_id
+		ORDER BY qc.quote_id, c.name`)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var id, name string
+		err = rows.Scan(&id, &name)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		characters[id] = append(characters[id], name)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = db.Query(`
+		SELECT q.id, e.season, e.wikiquote_title, l.raw, COALESCE(c.name, '')
+		FROM quotes q
+		JOIN episodes e ON e.id = q.episode_id
+		JOIN lines l ON l.quote_id = q.id
+		LEFT JOIN characters c ON c.id = l.character_id
+		ORDER BY e.season, e.number IS NULL, e.number, e.id, q.position, l.position`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	seasons := map[int]Season{}
+	for i, season := range getSeries() {
+		seasons[i+1] = Season{name: season.name}
+	}
+
+	for rows.Next() {
+		var id, episode, line, speaker string
+		var seasonNumber int
+		err = rows.Scan(&id, &seasonNumber, &episode, &line, &speaker)
+		if err != nil {
+			return nil, err
+		}
+
+		season := seasons[seasonNumber]
+		n := len(season.episodes)
+		if n == 0 || season.episodes[n-1].name != episode {
+			season.episodes = append(season.episodes, Episode{name: episode})
+			n++
+		}
+		ep := &season.episodes[n-1]
+		if len(ep.quotes) == 0 || ep.quotes[len(ep.quotes)-1].id != id {
+			ep.quotes = append(ep.quotes, Quote{id: id, season: seasonNumber, episode: episode, characters: characters[id]})
+		}
+		q := &ep.quotes[len(ep.quotes)-1]
+		q.lines = append(q.lines, line)
+		q.speakers = append(q.speakers, speaker)
+		seasons[seasonNumber] = season
+	}
+
+	return seasons, rows.Err()
+}
+
+// getDBFilmQuotes returns the quotes of a Season 5 film from the --db corpus
+func getDBFilmQuotes(film string) Season {
+	season := Season{name: dbSeasons[5].name}
+	for _, ep := range dbSeasons[5].episodes {
+		if matchesEpisode(ep.name, film) {
+			season.episodes = append(season.episodes, ep)
+		}
+	}
+
+	return season
+}
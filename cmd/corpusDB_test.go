@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadCorpusDBCharacters(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "corpus.db")
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	statements := []string{
+		corpusSchema,
+		fmt.Sprintf("PRAGMA user_version = %d", corpusSchemaVersion),
+		"INSERT INTO seasons (number, name) VALUES (1, 'Season 1')",
+		"INSERT INTO episodes (id, season, number, title, wikiquote_title, source) VALUES (1, 1, 1, 'Space Pilot 3000', 'Space Pilot 3000', '')",
+		"INSERT INTO quotes (id, episode_id, position) VALUES ('s01e01-00000000', 1, 0)",
+	}
+	for _, s := range statements {
+		if _, err := tx.Exec(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Leela only speaks on a line led by Fry
+	q := Quote{
+		id:         "s01e01-00000000",
+		lines:      []string{"Bender: Bite my shiny metal ass!", "Fry and Leela: Yes."},
+		speakers:   []string{"Bender", "Fry"},
+		characters: []string{"Bender", "Fry", "Leela"},
+	}
+	characterIDs := map[string]int64{}
+	getCharacterID := func(name string) (int64, error) {
+		if _, ok := characterIDs[name]; !ok {
+			result, err := tx.Exec("INSERT INTO characters (name) VALUES (?)", name)
+			if err != nil {
+				return 0, err
+			}
+			characterIDs[name], _ = result.LastInsertId()
+		}
+		return characterIDs[name], nil
+	}
+	addAlias := func(alias string, name string) error { return nil }
+	if err = writeQuoteLines(tx, q, getCharacterID, addAlias); err != nil {
+		t.Fatal(err)
+	}
+	if err = tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	seasons, err := loadCorpusDB(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := seasons[1].episodes[0].quotes[0]
+	if !reflect.DeepEqual(got.characters, q.characters) {
+		t.Errorf("got characters %q, want %q", got.characters, q.characters)
+	}
+	if !reflect.DeepEqual(got.speakers, q.speakers) {
+		t.Errorf("got speakers %q, want %q", got.speakers, q.speakers)
+	}
+}
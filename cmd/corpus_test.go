@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// testWiki answers parse API requests with the page text of the requested title, or a missing title error
+type testWiki struct {
+	pages    map[string]string
+	requests int
+}
+
+func (w *testWiki) RoundTrip(r *http.Request) (*http.Response, error) {
+	w.requests++
+	var body any = map[string]any{"error": map[string]string{"code": "missingtitle", "info": "The page you specified doesn't exist."}}
+	if text, ok := w.pages[r.URL.Query().Get("page")]; ok {
+		body = map[string]any{"parse": map[string]any{"title": r.URL.Query().Get("page"), "text": text}}
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(string(data)))}, nil
+}
+
+func useTestWiki(t *testing.T, pages map[string]string) *testWiki {
+	wiki := &testWiki{pages: pages}
+	transport := http.DefaultTransport
+	http.DefaultTransport = wiki
+	pageCache = map[string]Season{}
+	t.Cleanup(func() {
+		http.DefaultTransport = transport
+		pageCache = map[string]Season{}
+	})
+
+	return wiki
+}
+
+func TestGetPageQuotesError(t *testing.T) {
+	useTestWiki(t, map[string]string{})
+
+	_, err := getSeasonQuotesByNumber(1)
+	if err == nil || err.Error() != "The WikiQuote page Futurama/Season 1 does not exist." {
+		t.Errorf("got error %v, want missing page", err)
+	}
+	if _, ok := pageCache[getSeasonTitle(1)+"#"]; ok {
+		t.Error("failed page was cached")
+	}
+}
+
+func TestGetPageQuotesCache(t *testing.T) {
+	wiki := useTestWiki(t, map[string]string{getSeasonTitle(1): testSeasonPageNew})
+
+	for i := 0; i < 2; i++ {
+		season, err := getSeasonQuotesByNumber(1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(season.episodes) != 2 {
+			t.Errorf("got %d episodes, want 2", len(season.episodes))
+		}
+	}
+	if wiki.requests != 1 {
+		t.Errorf("got %d requests, want 1", wiki.requests)
+	}
+
+	// a section of the same page is cached separately
+	_, err := getPageQuotes(getSeasonTitle(1), "Dialogue", func(r io.Reader) (Season, error) {
+		return getSeasonFiveQuotes(r, 1, "")
+	})
+	if err == nil {
+		t.Error("got the cached page for a section")
+	}
+}
+
+func TestGetSectionWithoutNames(t *testing.T) {
+	wiki := useTestWiki(t, map[string]string{"Space Pilot 3000": ""})
+
+	_, err := wikipedia.getSection("Space Pilot 3000")
+	if err == nil {
+		t.Error("got no error without a section name")
+	}
+	if wiki.requests != 0 {
+		t.Errorf("got %d requests, want none", wiki.requests)
+	}
+}
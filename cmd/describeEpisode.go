@@ -2,142 +2,208 @@ package cmd
 
 import (
 	"fmt"
-	"io"
-	"log"
-	"net/http"
-	"regexp"
 	"strings"
 
 	"github.com/spf13/cobra"
-	"golang.org/x/net/html"
 )
 
 var DescribeEpisodeName string
 var SeasonIndex int
 var EpisodeIndex int
+var DescribeFields string
 
 var describeEpisodeCmd = &cobra.Command{
 	Use:   "episode",
 	Short: "Describe a Futurama episode (powered by Wikipedia)",
-	Long:  "Describe the plot of a user-defined Futurama episode",
+	Long: `Describe the plot of a user-defined Futurama episode, with the details from its Wikipedia infobox.
+
+Fields of the INFO section (all by default, see --fields):
+  season, episode, title, directors, writers, production-code, air-date, guests, opening-caption, opening-cartoon`,
 	Example: `  futurama describe episode --name "Space Pilot 3000"
+  futurama describe episode --name "Space Pilot 3000" --fields title,air-date,opening-caption
+  futurama describe episode --name "Space Pilot 3000" --format '{{.Title}}: {{index .Plot 0 | wrap 80}}'
   `,
 	// Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		var err error
 		err, SeasonIndex = validateEpisodeName(DescribeEpisodeName)
+		if err == nil {
+			err = validateTemplate()
+		}
+		if err == nil {
+			_, err = getDescribeFields(DescribeFields)
+		}
 		if err != nil {
 			fmt.Println(err)
 			fmt.Println()
 			cmd.Help()
-		} else {
-			describeEpisode()
+		} else if err = describeEpisode(); err != nil {
+			fmt.Println(err)
 		}
 	},
 }
 
 func init() {
 	describeCmd.AddCommand(describeEpisodeCmd)
+	addTemplateFlags(describeEpisodeCmd.Flags())
 	describeEpisodeCmd.Flags().StringVarP(&DescribeEpisodeName, "name", "n", "", "Episode name (use `futurama get episodes` command for assistance)")
+	describeEpisodeCmd.Flags().StringVar(&DescribeFields, "fields", "", "Comma-separated fields of the INFO section to show (default is all, e.g. 'title,air-date')")
 }
 
-func describeEpisode() {
-	var resp *http.Response
-
-	urlEpisodeName := strings.Replace(strings.Replace(DescribeEpisodeName, "'", "%27", -1), " ", "_", -1)
-	if DescribeEpisodeName == "A Farewell to Arms" {
-		urlEpisodeName = urlEpisodeName + "_(Futurama)"
-	}
-
-	resp = getHttpResponse("https://en.wikipedia.org/wiki/" + urlEpisodeName)
-	defer resp.Body.Close()
-
-	tokenizer := html.NewTokenizer(resp.Body)
-	plot := []string{}
-wikiLoop:
-	for { // loop until
-		switch tokenizer.Next() {
-		case html.ErrorToken:
-			err := tokenizer.Err()
-			if err == io.EOF {
-				break wikiLoop //end of the file, break out of the loop
-			}
-			log.Fatalf("error tokenizing HTML: %v", tokenizer.Err())
-		case html.StartTagToken:
-			token := tokenizer.Token()
-			if "span" == token.Data {
-				for _, attr := range token.Attr {
-					if attr.Val == "Plot" { // found plot section
-						for {
-							switch tokenizer.Next() {
-							case html.ErrorToken:
-								err := tokenizer.Err()
-								if err == io.EOF {
-									break wikiLoop //end of the file, break out of the loop
-								}
-							case html.StartTagToken:
-								token := tokenizer.Token()
-								if "p" == token.Data || "h3" == token.Data { // start of plot paragraph
-									para := ""
-								paragraphLoop:
-									for {
-										switch tokenizer.Next() {
-										case html.ErrorToken:
-											err := tokenizer.Err()
-											if err == io.EOF {
-												break wikiLoop // end of the file, break out of the loop
-											}
-										case html.TextToken:
-											token := tokenizer.Token()
-											para = para + string(token.Data) // append plot text
-										case html.EndTagToken:
-											token := tokenizer.Token()
-											if "p" == token.Data || "h3" == token.Data {
-												plot = append(plot, para)
-												break paragraphLoop
-											}
-										}
-									}
-								} else if "h2" == token.Data { // end of plot section
-									break wikiLoop
-								}
-							}
-						}
-					}
-				}
-			}
+func describeEpisode() error {
+	fields, err := getDescribeFields(DescribeFields)
+	if err != nil {
+		return err
+	}
+
+	title := getWikipediaTitle(DescribeEpisodeName)
+	info := EpisodeInfo{}
+	if hasInfoboxField(fields) {
+		info, err = getEpisodeInfo(title)
+		if err != nil {
+			return err
 		}
 	}
 
-	// remove edit links
-	editEx := regexp.MustCompile(`\[edit\]`)
-	for i, para := range plot {
-		plot[i] = editEx.ReplaceAllString(para, "")
+	// a missing plot doesn't prevent showing the other fields, the error is reported in its place
+	plot, plotErr := getEpisodePlot(title)
+	if plot == nil {
+		plot = []string{}
+	}
+	doc := newDescriptionDocument(plot, info, fields, title)
+	if plotErr != nil {
+		doc.PlotError = plotErr.Error()
+	}
+
+	printDescription(doc)
+	return nil
+}
+
+// formatGuest indents a guest appearance under the Guests field, wrapped to width with its continuation lines indented further
+func formatGuest(guest string, width int) string {
+	if width > 0 {
+		width -= 2 // wrapIndent drops leading spaces, so the indent is added after wrapping
+	}
+	lines := strings.Split(wrapIndent(guest, width, 2), "\n")
+	for i := range lines {
+		lines[i] = "  " + lines[i]
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// getWikipediaTitle returns the title of an episode's Wikipedia page
+func getWikipediaTitle(episode string) string {
+	if episode == "A Farewell to Arms" {
+		return episode + " (Futurama)"
+	}
+
+	return episode
+}
+
+// newDescriptionDocument builds the description of an episode with the fields selected by --fields
+func newDescriptionDocument(plot []string, info EpisodeInfo, fields map[string]bool, title string) DescriptionDocument {
+	links := []string{
+		wikipedia.pageURL(title),
+		"https://theinfosphere.org/" + encodeWikiTitle(title),
+		"https://futurama.fandom.com/wiki/" + encodeWikiTitle(title),
 	}
 
-	printDescription(plot, urlEpisodeName)
+	doc := DescriptionDocument{Plot: plot, Source: links[0], Links: links}
+	if fields[fieldSeason] {
+		doc.Season = SeasonIndex
+	}
+	if fields[fieldEpisode] {
+		doc.Episode = EpisodeIndex
+	}
+	if fields[fieldTitle] {
+		doc.Title = DescribeEpisodeName
+	}
+	if fields[fieldDirectors] {
+		doc.Directors = info.Directors
+	}
+	if fields[fieldWriters] {
+		doc.Writers = info.Writers
+	}
+	if fields[fieldProductionCode] {
+		doc.ProductionCode = info.ProductionCode
+	}
+	if fields[fieldAirDate] {
+		doc.AirDate = info.AirDate
+	}
+	if fields[fieldGuests] {
+		doc.Guests = info.Guests
+	}
+	if fields[fieldOpeningCaption] {
+		doc.OpeningCaption = info.OpeningCaption
+	}
+	if fields[fieldOpeningCartoon] {
+		doc.OpeningCartoon = info.OpeningCartoon
+	}
 
+	return doc
 }
 
-func printDescription(plot []string, urlEpisodeName string) {
-	fmt.Println("\nINFO")
+// printDescription prints the INFO section (the fields set in doc), the plot, or why it couldn't be retrieved, and links
+func printDescription(doc DescriptionDocument) {
+	if isStructuredOutput() {
+		err := writeDocument(doc, []any{doc})
+		if err != nil {
+			fmt.Println(err)
+		}
+		return
+	}
+
+	defer startPager()()
+
+	fmt.Println("\n" + colorize(theme.Header, "INFO"))
 	fmt.Println("----")
-	fmt.Print("Season: ")
-	fmt.Println(SeasonIndex)
-	fmt.Print("Episode: ")
-	fmt.Println(EpisodeIndex)
-	fmt.Print("Title: ")
-	fmt.Println(DescribeEpisodeName)
-
-	fmt.Println("\nPLOT")
+	if doc.Season != 0 {
+		printField("Season", doc.Season)
+	}
+	if doc.Episode != 0 {
+		printField("Episode", doc.Episode)
+	}
+	for _, field := range []struct {
+		label string
+		value string
+	}{
+		{"Title", doc.Title},
+		{"Directed by", strings.Join(doc.Directors, ", ")},
+		{"Written by", strings.Join(doc.Writers, ", ")},
+		{"Production code", doc.ProductionCode},
+		{"Air date", doc.AirDate},
+	} {
+		if field.value != "" {
+			printField(field.label, field.value)
+		}
+	}
+	if len(doc.Guests) > 0 { // one guest per line
+		fmt.Println(colorize(theme.Header, "Guests:"))
+		for _, guest := range doc.Guests {
+			fmt.Println(formatGuest(guest, wrapWidth))
+		}
+	}
+	if doc.OpeningCaption != "" {
+		printField("Opening caption", doc.OpeningCaption)
+	}
+	if doc.OpeningCartoon != "" {
+		printField("Opening cartoon", doc.OpeningCartoon)
+	}
+
+	fmt.Println("\n" + colorize(theme.Header, "PLOT"))
 	fmt.Println("----")
-	for _, line := range plot {
-		fmt.Println(line)
+	if doc.PlotError != "" {
+		fmt.Println(doc.PlotError)
+		fmt.Println()
+	}
+	for _, line := range doc.Plot {
+		fmt.Println(wordWrap(wrapWidth, line))
 	}
 
-	fmt.Println("LINKS")
+	fmt.Println(colorize(theme.Header, "LINKS"))
 	fmt.Println("----")
-	fmt.Println("https://en.wikipedia.org/wiki/" + urlEpisodeName)
-	fmt.Println("https://theinfosphere.org/" + urlEpisodeName)
-	fmt.Println("https://futurama.fandom.com/wiki/" + urlEpisodeName)
+	for _, link := range doc.Links {
+		fmt.Println(link)
+	}
 }
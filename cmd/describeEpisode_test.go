@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+// captureStdout returns what f prints to stdout
+func captureStdout(t *testing.T, f func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	done := make(chan []byte)
+	go func() {
+		data, _ := io.ReadAll(r)
+		done <- data
+	}()
+	f()
+	w.Close()
+
+	return string(<-done)
+}
+
+func TestDescribeEpisodePlotError(t *testing.T) {
+	useTestWiki(t, map[string]string{
+		"Space Pilot 3000": `<table class="infobox"><tr><th>Original air date</th><td>March 28, 1999</td></tr></table>`,
+	})
+	output, fields := OutputFormat, DescribeFields
+	t.Cleanup(func() { OutputFormat, DescribeFields = output, fields })
+	OutputFormat, DescribeFields = outputJSON, fieldAirDate
+	DescribeEpisodeName, SeasonIndex, EpisodeIndex = "Space Pilot 3000", 1, 1
+
+	var err error
+	out := captureStdout(t, func() { err = describeEpisode() })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var doc DescriptionDocument
+	if err = json.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatalf("%v in %s", err, out)
+	}
+	if doc.AirDate != "March 28, 1999" {
+		t.Errorf("got air date %q, want March 28, 1999", doc.AirDate)
+	}
+	if want := "No Plot section was found on the Wikipedia page of Space Pilot 3000."; doc.PlotError != want {
+		t.Errorf("got plot error %q, want %q", doc.PlotError, want)
+	}
+	if len(doc.Plot) != 0 {
+		t.Errorf("got plot %q, want none", doc.Plot)
+	}
+}
+
+func TestFormatGuest(t *testing.T) {
+	guest := "Dick Clark as himself and the head of a famous television host"
+	tests := []struct {
+		width int
+		want  string
+	}{
+		{0, "  " + guest},
+		{80, "  " + guest},
+		{30, "  Dick Clark as himself and\n    the head of a famous\n    television host"},
+	}
+
+	for _, tt := range tests {
+		got := formatGuest(guest, tt.width)
+		if got != tt.want {
+			t.Errorf("width %d: got %q, want %q", tt.width, got, tt.want)
+		}
+		for _, line := range strings.Split(got, "\n") {
+			if tt.width > 0 && len(line) > tt.width {
+				t.Errorf("width %d: line %q is too long", tt.width, line)
+			}
+		}
+	}
+}
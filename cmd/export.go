@@ -0,0 +1,75 @@
+/*
+Copyright © 2023 Aric Hansen <aric.p.hansen@gmail.com>
+*/
+package cmd
+
+import (
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var ExportFile string
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export quotes to other formats",
+	Long: `Export Futurama quotes to formats used by other tools, such as screenplays, fortune(6) and Anki.
+
+Exports are printed to stdout, or written to --output-file.`,
+	Example: `  futurama export script --episode "Space Pilot 3000"
+  futurama export script --episode "Godfellas" --format pdf-ready-text -o godfellas.txt
+  futurama export fortune -o futurama
+  futurama export anki --season 1 -o season1.tsv`,
+	Args: cobra.ExactArgs(1),
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.PersistentFlags().StringVarP(&ExportFile, "output-file", "o", "", "Path of the exported file (default is stdout)")
+}
+
+// nopCloser keeps stdout open once an export is done
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error { return nil }
+
+// createExportWriter opens --output-file, or returns stdout if no output file is provided
+func createExportWriter() (io.WriteCloser, error) {
+	if ExportFile == "" {
+		return nopCloser{os.Stdout}, nil
+	}
+
+	return os.Create(ExportFile)
+}
+
+// addExportFilterFlags registers the flags selecting which quotes are exported, as used by 'get quote'
+func addExportFilterFlags(flags *pflag.FlagSet) {
+	flags.IntVarP(&QuoteSeason, "season", "s", 0, "Only quotes from this season (1-7)")
+	flags.StringVarP(&QuoteEpisode, "episode", "e", "", "Only quotes from this episode (use 'futurama get episodes' command for assistance)")
+	flags.StringVarP(&QuoteCharacter, "character", "c", "", "Only quotes where this character speaks (e.g. 'Fry', 'Bender')")
+	addSpeakerFlags(flags)
+	addLengthFlags(flags)
+}
+
+// getExportQuotes validates the export filters, and retrieves every quote matching them in corpus order
+func getExportQuotes() ([]Quote, error) {
+	err := validateQuoteScope()
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := getFilteredPool()
+	if err != nil {
+		return nil, err
+	}
+	if len(pool) == 0 {
+		return nil, noQuotesError()
+	}
+
+	return pool, nil
+}
@@ -0,0 +1,113 @@
+/*
+Copyright © 2023 Aric Hansen <aric.p.hansen@gmail.com>
+*/
+package cmd
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var AnkiDeck string
+
+var exportAnkiCmd = &cobra.Command{
+	Use:   "anki",
+	Short: "Export quotes as Anki flashcards",
+	Long: `Export every quote, or the quotes matching the filters, as "who said it" flashcards in Anki's
+importable TSV format (File > Import in Anki).
+
+The front of each card shows the quote with the speakers hidden. The back shows the speakers,
+episode and season, with a link to the plot on Wikipedia. Cards are tagged by season and character
+(e.g. futurama::season_1 and futurama::character::Fry), and use the quote ID as their identifier,
+so importing a new export updates existing cards instead of duplicating them.`,
+	Example: `  futurama export anki -o futurama.tsv
+  futurama export anki --season 2 --deck "Futurama S2" -o season2.tsv
+  futurama export anki --with Fry --with Leela --max-lines 4 -o fry-and-leela.tsv`,
+	Run: func(cmd *cobra.Command, args []string) {
+		err := exportAnki()
+		if err != nil {
+			fmt.Println(err)
+		}
+	},
+}
+
+func init() {
+	exportCmd.AddCommand(exportAnkiCmd)
+	exportAnkiCmd.Flags().StringVar(&AnkiDeck, "deck", "Futurama", "Name of the Anki deck the cards are imported into")
+	addExportFilterFlags(exportAnkiCmd.Flags())
+}
+
+func exportAnki() error {
+	quotes, err := getExportQuotes()
+	if err != nil {
+		return err
+	}
+
+	w, err := createExportWriter()
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+
+	// file headers read by Anki's importer (2.1.55+)
+	fmt.Fprintln(w, "#separator:tab")
+	fmt.Fprintln(w, "#html:true")
+	fmt.Fprintln(w, "#notetype:Basic")
+	fmt.Fprintln(w, "#deck:"+AnkiDeck)
+	fmt.Fprintln(w, "#guid column:1")
+	fmt.Fprintln(w, "#tags column:4")
+
+	for _, q := range quotes {
+		fields := []string{q.id, getAnkiFront(q), getAnkiBack(q), strings.Join(getAnkiTags(q), " ")}
+		for i, field := range fields {
+			fields[i] = strings.ReplaceAll(field, "\t", " ")
+		}
+		fmt.Fprintln(w, strings.Join(fields, "\t"))
+	}
+
+	if ExportFile != "" {
+		fmt.Printf("Exported %d cards to %s.\n", len(quotes), ExportFile)
+	}
+	return nil
+}
+
+// getAnkiFront shows the lines of a quote with every speaker's name hidden
+func getAnkiFront(q Quote) string {
+	lines := []string{}
+	for _, line := range newQuoteDocument(q).Lines {
+		if line.Speaker == "" {
+			lines = append(lines, "<i>"+html.EscapeString(line.Text)+"</i>")
+		} else {
+			lines = append(lines, "<b>???:</b> "+html.EscapeString(line.Text))
+		}
+	}
+
+	return strings.Join(lines, "<br>") + "<br><br>Who said it?"
+}
+
+// getAnkiBack shows who said a quote, and where
+func getAnkiBack(q Quote) string {
+	episode := q.episode
+	if n := getEpisodeNumber(q.season, q.episode); n != 0 {
+		episode = getSeries()[q.season-1].episodes[n-1]
+	}
+
+	back := "<b>" + html.EscapeString(strings.Join(q.characters, ", ")) + "</b><br>"
+	back += fmt.Sprintf("%s (Season %d)<br>", html.EscapeString(episode), q.season)
+	back += `<a href="` + wikipedia.pageURL(getWikipediaTitle(episode)) + `">Plot on Wikipedia</a>`
+
+	return back
+}
+
+// getAnkiTags tags a card by season and character; Anki tags cannot contain spaces
+func getAnkiTags(q Quote) []string {
+	tags := []string{fmt.Sprintf("futurama::season_%d", q.season)}
+	for _, c := range q.characters {
+		tags = append(tags, "futurama::character::"+strings.ReplaceAll(c, " ", "_"))
+	}
+
+	return tags
+}
@@ -0,0 +1,128 @@
+/*
+Copyright © 2023 Aric Hansen <aric.p.hansen@gmail.com>
+*/
+package cmd
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// fortunes are wrapped like the classic fortune files
+const fortuneWidth = 72
+
+// version of the strfile(8) index format written next to fortune files
+const strfileVersion = 2
+
+var exportFortuneCmd = &cobra.Command{
+	Use:   "fortune",
+	Short: "Export quotes as a fortune(6) database",
+	Long: `Export every quote, or the quotes matching the filters, in the %-delimited format used by fortune(6),
+each attributed with "— Speaker, Episode".
+
+With --output-file, the matching strfile(8) index (<file>.dat) is written next to it, so both files
+can be copied straight into the fortunes directory (e.g. /usr/share/games/fortunes).`,
+	Example: `  futurama export fortune -o futurama
+  futurama export fortune --character Bender --max-chars 200 -o bender
+  sudo cp futurama futurama.dat /usr/share/games/fortunes/`,
+	Run: func(cmd *cobra.Command, args []string) {
+		err := exportFortune()
+		if err != nil {
+			fmt.Println(err)
+		}
+	},
+}
+
+func init() {
+	exportCmd.AddCommand(exportFortuneCmd)
+	addExportFilterFlags(exportFortuneCmd.Flags())
+}
+
+func exportFortune() error {
+	quotes, err := getExportQuotes()
+	if err != nil {
+		return err
+	}
+
+	fortunes := []string{}
+	for _, q := range quotes {
+		fortunes = append(fortunes, formatFortune(q))
+	}
+
+	var b bytes.Buffer
+	for _, f := range fortunes {
+		b.WriteString(f)
+		b.WriteString("%\n")
+	}
+
+	if ExportFile == "" {
+		_, err = os.Stdout.Write(b.Bytes())
+		return err
+	}
+
+	err = os.WriteFile(ExportFile, b.Bytes(), 0644)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(ExportFile+".dat", getStrfileIndex(fortunes), 0644)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Exported %d quotes to %s and %s.\n", len(fortunes), ExportFile, ExportFile+".dat")
+	return nil
+}
+
+// formatFortune formats a quote as a fortune: its wrapped lines, then the speakers and episode
+func formatFortune(q Quote) string {
+	var b strings.Builder
+	for i, line := range q.lines {
+		line = strings.TrimSpace(line)
+		indent := 0
+		if i < len(q.speakers) && q.speakers[i] != "" {
+			indent = len(speakerPrefix.FindString(line))
+		}
+		b.WriteString(wrapIndent(line, fortuneWidth, indent) + "\n")
+	}
+
+	attribution := q.episode
+	if len(q.characters) > 0 {
+		attribution = strings.Join(q.characters, " & ") + ", " + q.episode
+	}
+	b.WriteString("\t\t— " + attribution + "\n")
+
+	return b.String()
+}
+
+// getStrfileIndex builds the strfile(8) index of fortunes separated by "%" lines: a header with
+// the number of fortunes, the lengths of the longest and shortest, the flags and the delimiter,
+// then the offset of each fortune in the file and the offset of the end of the file, all big-endian
+func getStrfileIndex(fortunes []string) []byte {
+	longest, shortest := uint32(0), uint32(0)
+	offsets := []uint32{0}
+	offset := uint32(0)
+	for i, f := range fortunes {
+		length := uint32(len(f))
+		if length > longest {
+			longest = length
+		}
+		if i == 0 || length < shortest {
+			shortest = length
+		}
+		offset += length + uint32(len("%\n"))
+		offsets = append(offsets, offset)
+	}
+
+	var b bytes.Buffer
+	header := []uint32{strfileVersion, uint32(len(fortunes)), longest, shortest, 0}
+	binary.Write(&b, binary.BigEndian, header)
+	b.Write([]byte{'%', 0, 0, 0})
+	binary.Write(&b, binary.BigEndian, offsets)
+
+	return b.Bytes()
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+)
+
+func TestGetStrfileIndex(t *testing.T) {
+	fortunes := []string{"Fry: Yes.\n", "Bender: Bite my shiny metal ass!\n", "Leela: No.\n"}
+	index := getStrfileIndex(fortunes)
+
+	// the header is 5 uint32, the delimiter padded to 4 bytes, then one offset per fortune and the end of the file
+	if want := 5*4 + 4 + (len(fortunes)+1)*4; len(index) != want {
+		t.Fatalf("got %d bytes, want %d", len(index), want)
+	}
+
+	var header [5]uint32
+	err := binary.Read(bytes.NewReader(index[:20]), binary.BigEndian, &header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [5]uint32{2, 3, uint32(len(fortunes[1])), uint32(len(fortunes[0])), 0}
+	if header != want {
+		t.Errorf("got header %v, want %v (version, numstr, longlen, shortlen, flags)", header, want)
+	}
+
+	if delim := index[20:24]; !bytes.Equal(delim, []byte{'%', 0, 0, 0}) {
+		t.Errorf("got delimiter %q, want %q", delim, "%\x00\x00\x00")
+	}
+
+	file := ""
+	for _, f := range fortunes {
+		file += f + "%\n"
+	}
+	offsets := make([]uint32, len(fortunes)+1)
+	err = binary.Read(bytes.NewReader(index[24:]), binary.BigEndian, offsets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, f := range fortunes {
+		if !strings.HasPrefix(file[offsets[i]:], f+"%\n") {
+			t.Errorf("offset %d is %d, which does not start fortune %q", i, offsets[i], f)
+		}
+	}
+	if end := offsets[len(fortunes)]; end != uint32(len(file)) {
+		t.Errorf("got end offset %d, want the file length %d", end, len(file))
+	}
+}
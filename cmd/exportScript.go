@@ -0,0 +1,194 @@
+/*
+Copyright © 2023 Aric Hansen <aric.p.hansen@gmail.com>
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/spf13/cobra"
+)
+
+var ScriptEpisode string
+var ScriptFormat string
+
+// supported values for the export script --format flag
+const (
+	scriptFountain = "fountain"       // Fountain markup (https://fountain.io), for screenwriting apps
+	scriptText     = "pdf-ready-text" // paginated plain text laid out like a printed screenplay
+)
+
+// lines per page of a pdf-ready-text script
+const scriptPageLength = 55
+
+var exportScriptCmd = &cobra.Command{
+	Use:   "script",
+	Short: "Export every quote from an episode as a screenplay",
+	Long: `Export every quote from an episode as a screenplay, with character cues, parenthetical
+stage directions and indented dialogue. Each quote on WikiQuote is a separate exchange, so the script
+cuts from one to the next.
+
+Available formats:
+  - fountain: Fountain markup, which screenwriting apps can import (default)
+  - pdf-ready-text: paginated plain text, ready to be printed or converted to PDF in a monospaced font
+
+The speaker and length filters of 'get quote' are not available, the script keeps every quote of the episode.`,
+	Example: `  futurama export script --episode "Space Pilot 3000"
+  futurama export script --episode "Godfellas" --format pdf-ready-text -o godfellas.txt`,
+	Run: func(cmd *cobra.Command, args []string) {
+		err := exportScript()
+		if err != nil {
+			fmt.Println(err)
+		}
+	},
+}
+
+func init() {
+	exportCmd.AddCommand(exportScriptCmd)
+	exportScriptCmd.Flags().StringVarP(&ScriptEpisode, "episode", "e", "", "Episode name (use 'futurama get episodes' command for assistance)")
+	exportScriptCmd.Flags().StringVar(&ScriptFormat, "format", scriptFountain, "Format of the script (fountain, pdf-ready-text)")
+	exportScriptCmd.MarkFlagRequired("episode")
+}
+
+func exportScript() error {
+	if ScriptFormat != scriptFountain && ScriptFormat != scriptText {
+		return errors.New("Invalid script format. Please select one of: fountain, pdf-ready-text.")
+	}
+
+	err, seasonNumber := validateEpisodeName(ScriptEpisode)
+	if err != nil {
+		return err
+	}
+
+	var season Season
+	if seasonNumber == 5 {
+		season, err = getFilmQuotes(ScriptEpisode)
+	} else {
+		season, err = getSeasonQuotesByNumber(seasonNumber)
+	}
+	if err != nil {
+		return err
+	}
+
+	quotes := []Quote{}
+	for _, ep := range season.episodes {
+		if matchesEpisode(ep.name, ScriptEpisode) {
+			quotes = getEpisodePool(ep)
+		}
+	}
+	if len(quotes) == 0 {
+		return errors.New("No quotes found for " + ScriptEpisode + ".")
+	}
+
+	w, err := createExportWriter()
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+
+	if ScriptFormat == scriptText {
+		return writeScriptText(w, quotes)
+	}
+	return writeFountain(w, quotes)
+}
+
+// getEpisodeScript lists the screenplay elements of an episode's quotes, under a scene heading with its title.
+// Quotes are separate exchanges on WikiQuote, so a transition cuts from each one to the next.
+func getEpisodeScript(quotes []Quote) []scriptElement {
+	elements := []scriptElement{{kind: elementScene, text: quotes[0].episode}}
+	for i, q := range quotes {
+		if i > 0 {
+			elements = append(elements, scriptElement{kind: elementTransition, text: "CUT TO:"})
+		}
+		elements = append(elements, getScriptElements(q)...)
+	}
+
+	return elements
+}
+
+// writeFountain writes quotes as a Fountain document, with a title page crediting WikiQuote
+func writeFountain(w io.Writer, quotes []Quote) error {
+	q := quotes[0]
+	fmt.Fprintln(w, "Title: "+q.episode)
+	fmt.Fprintf(w, "Credit: Futurama, Season %d\n", q.season)
+	fmt.Fprintln(w, "Author: WikiQuote contributors")
+	fmt.Fprintln(w, "Source: "+getQuoteSourceURL(q)+" (CC BY-SA)")
+
+	previous := ""
+	for _, e := range getEpisodeScript(quotes) {
+		switch e.kind {
+		case elementScene:
+			fmt.Fprintln(w, "\n."+strings.ToUpper(e.text)) // "." forces a scene heading without INT./EXT.
+		case elementAction:
+			if e.text == strings.ToUpper(e.text) {
+				fmt.Fprintln(w, "\n!"+e.text) // "!" keeps all-caps action from being read as a character cue
+			} else {
+				fmt.Fprintln(w, "\n"+e.text)
+			}
+		case elementTransition:
+			fmt.Fprintln(w, "\n> "+e.text) // ">" forces a transition
+		case elementCharacter:
+			if strings.IndexFunc(e.text, unicode.IsLetter) == -1 {
+				fmt.Fprintln(w, "\n@"+e.text) // "@" forces a character cue without letters
+			} else {
+				fmt.Fprintln(w, "\n"+e.text)
+			}
+		case elementParenthetical, elementDialogue:
+			if previous == elementDialogue && e.kind == elementDialogue {
+				fmt.Fprintln(w, "  ") // two spaces keep dialogue paragraphs in the same block
+			}
+			fmt.Fprintln(w, e.text)
+		}
+		previous = e.kind
+	}
+
+	return nil
+}
+
+// writeScriptText writes quotes as a paginated screenplay: a title page, then pages of scriptPageLength lines
+// separated by form feeds and numbered from page 2, like a printed script
+func writeScriptText(w io.Writer, quotes []Quote) error {
+	q := quotes[0]
+	center := func(text string) string {
+		indent := (screenplayWidth - len(text)) / 2
+		if indent < 0 {
+			indent = 0
+		}
+		return strings.Repeat(" ", indent) + text
+	}
+
+	fmt.Fprint(w, strings.Repeat("\n", 20))
+	fmt.Fprintln(w, center(strings.ToUpper(q.episode)))
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, center(fmt.Sprintf("Futurama, Season %d", q.season)))
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, center("Quotes from WikiQuote (CC BY-SA)"))
+	fmt.Fprintln(w, center(getQuoteSourceURL(q)))
+
+	page := 0
+	remaining := 0
+	for _, block := range renderScreenplay(getEpisodeScript(quotes), screenplayWidth, false) {
+		if remaining < len(block)+1 { // block and the blank line before it don't fit, start a new page
+			page++
+			remaining = scriptPageLength
+			fmt.Fprint(w, "\f")
+			if page > 1 {
+				fmt.Fprintf(w, "%*s\n\n", screenplayWidth, fmt.Sprintf("%d.", page))
+				remaining -= 2
+			}
+		} else {
+			fmt.Fprintln(w)
+			remaining--
+		}
+		for _, line := range block {
+			fmt.Fprintln(w, line)
+		}
+		remaining -= len(block)
+	}
+
+	return nil
+}
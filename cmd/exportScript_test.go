@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetEpisodeScript(t *testing.T) {
+	quotes := []Quote{
+		{episode: "Space Pilot 3000", lines: []string{"Fry: Yes."}, speakers: []string{"Fry"}},
+		{episode: "Space Pilot 3000", lines: []string{"Leela: No."}, speakers: []string{"Leela"}},
+	}
+
+	want := []scriptElement{
+		{kind: elementScene, text: "Space Pilot 3000"},
+		{kind: elementCharacter, text: "FRY", speaker: "Fry"},
+		{kind: elementDialogue, text: "Yes.", speaker: "Fry"},
+		{kind: elementTransition, text: "CUT TO:"},
+		{kind: elementCharacter, text: "LEELA", speaker: "Leela"},
+		{kind: elementDialogue, text: "No.", speaker: "Leela"},
+	}
+	if got := getEpisodeScript(quotes); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
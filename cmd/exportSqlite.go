@@ -0,0 +1,294 @@
+/*
+Copyright © 2023 Aric Hansen <aric.p.hansen@gmail.com>
+*/
+package cmd
+
+import (
+	"database/sql"
+	_ "embed"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	_ "modernc.org/sqlite"
+)
+
+var SQLiteNoPlots bool
+
+// version of the corpus schema, stored in PRAGMA user_version. Bump it on any change to sqlite/schema.sql.
+const corpusSchemaVersion = 2
+
+//go:embed sqlite/schema.sql
+var corpusSchema string
+
+var exportSqliteCmd = &cobra.Command{
+	Use:   "sqlite <file>",
+	Short: "Export the whole corpus to a SQLite database",
+	Long: `Export every season, episode, character, quote, line and plot to a SQLite database, in normalized
+tables with a full-text search index of the quotes (see docs/sqlite.md for the schema).
+
+The database can then be used instead of WikiQuote with the global --db flag, e.g. to work offline.
+It always holds the whole corpus, so the speaker and length filters of 'get quote' are not available.`,
+	Example: `  futurama export sqlite corpus.db
+  futurama export sqlite corpus.db --no-plots
+  futurama get quote --db corpus.db --character Bender`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		path := ExportFile
+		if len(args) > 0 {
+			path = args[0]
+		}
+
+		var err error
+		if path == "" {
+			err = errors.New("Please provide the path of the database (e.g. 'futurama export sqlite corpus.db').")
+		} else {
+			err = exportSQLite(path)
+		}
+		if err != nil {
+			fmt.Println(err)
+		}
+	},
+}
+
+func init() {
+	exportCmd.AddCommand(exportSqliteCmd)
+	exportSqliteCmd.Flags().BoolVar(&SQLiteNoPlots, "no-plots", false, "Toggle for skipping plots, which are retrieved from Wikipedia one episode at a time")
+}
+
+// exportSQLite writes the corpus to a new database, which replaces path once complete
+func exportSQLite(path string) error {
+	tmp := path + ".tmp"
+	os.Remove(tmp)
+
+	db, err := sql.Open("sqlite", tmp)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	count, err := writeCorpus(tx)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err == nil {
+		err = db.Close()
+	}
+	if err != nil {
+		return err
+	}
+	err = os.Rename(tmp, path)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Exported %d quotes to %s.\n", count, path)
+	return nil
+}
+
+// writeCorpus creates the schema and inserts the corpus, returning the number of quotes
+func writeCorpus(tx *sql.Tx) (int, error) {
+	statements := []string{corpusSchema, fmt.Sprintf("PRAGMA user_version = %d", corpusSchemaVersion)}
+	for _, s := range statements {
+		if _, err := tx.Exec(s); err != nil {
+			return 0, err
+		}
+	}
+
+	metadata := map[string]string{
+		"schema_version":   fmt.Sprint(corpusSchemaVersion),
+		"futurama_version": Version,
+		"exported_at":      time.Now().UTC().Format(time.RFC3339),
+		"license":          "Quotes from WikiQuote and plots from Wikipedia, available under CC BY-SA 4.0",
+	}
+	for key, value := range metadata {
+		if _, err := tx.Exec("INSERT INTO metadata (key, value) VALUES (?, ?)", key, value); err != nil {
+			return 0, err
+		}
+	}
+
+	series := getSeries()
+	for i, season := range series {
+		if _, err := tx.Exec("INSERT INTO seasons (number, name) VALUES (?, ?)", i+1, season.name); err != nil {
+			return 0, err
+		}
+		for x, title := range season.episodes {
+			_, err := tx.Exec("INSERT INTO episodes (season, number, title, wikiquote_title, source) VALUES (?, ?, ?, ?, ?)",
+				i+1, x+1, title, title, getQuoteSourceURL(Quote{season: i + 1, episode: title}))
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	// characters, created as they are found
+	characterIDs := map[string]int64{}
+	getCharacterID := func(name string) (int64, error) {
+		if id, ok := characterIDs[name]; ok {
+			return id, nil
+		}
+		result, err := tx.Exec("INSERT INTO characters (name) VALUES (?)", name)
+		if err != nil {
+			return 0, err
+		}
+		characterIDs[name], err = result.LastInsertId()
+		return characterIDs[name], err
+	}
+	addAlias := func(alias string, name string) error {
+		id, err := getCharacterID(name)
+		if err == nil {
+			_, err = tx.Exec("INSERT OR IGNORE INTO character_aliases (alias, character_id) VALUES (?, ?)", alias, id)
+		}
+		return err
+	}
+	for _, n := range getPossibleNames() {
+		for _, alias := range n.alternatives {
+			if err := addAlias(alias, n.normalizedName); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	seasons, err := getSeriesQuotes()
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for i, season := range seasons {
+		seasonNumber := i + 1
+		for _, ep := range season.episodes {
+			// episodes known to futurama are already listed, under the name used by getSeries
+			var episodeID int64
+			var err error
+			if number := getEpisodeNumber(seasonNumber, ep.name); number != 0 {
+				_, err = tx.Exec("UPDATE episodes SET wikiquote_title = ? WHERE season = ? AND number = ?", ep.name, seasonNumber, number)
+			} else {
+				_, err = tx.Exec("INSERT OR IGNORE INTO episodes (season, title, wikiquote_title, source) VALUES (?, ?, ?, ?)",
+					seasonNumber, ep.name, ep.name, getQuoteSourceURL(Quote{season: seasonNumber, episode: ep.name}))
+			}
+			if err != nil {
+				return 0, err
+			}
+			err = tx.QueryRow("SELECT id FROM episodes WHERE season = ? AND wikiquote_title = ?", seasonNumber, ep.name).Scan(&episodeID)
+			if err != nil {
+				return 0, err
+			}
+
+			// an episode can be listed twice on a page, its quotes keep their order
+			position := 0
+			err = tx.QueryRow("SELECT COUNT(*) FROM quotes WHERE episode_id = ?", episodeID).Scan(&position)
+			if err != nil {
+				return 0, err
+			}
+
+			for _, q := range getEpisodePool(ep) {
+				_, err := tx.Exec("INSERT INTO quotes (id, episode_id, position) VALUES (?, ?, ?)",
+					q.id, episodeID, position)
+				if err != nil {
+					return 0, err
+				}
+				position++
+				count++
+
+				err = writeQuoteLines(tx, q, getCharacterID, addAlias)
+				if err != nil {
+					return 0, err
+				}
+			}
+		}
+	}
+
+	if !SQLiteNoPlots {
+		err := writePlots(tx)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return count, nil
+}
+
+// writeQuoteLines inserts the characters and lines of a quote, and its full-text search entry
+func writeQuoteLines(tx *sql.Tx, q Quote, getCharacterID func(string) (int64, error), addAlias func(string, string) error) error {
+	for _, name := range q.characters {
+		id, err := getCharacterID(name)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec("INSERT INTO quote_characters (quote_id, character_id) VALUES (?, ?)", q.id, id)
+		if err != nil {
+			return err
+		}
+	}
+
+	doc := newQuoteDocument(q)
+	for i, line := range doc.Lines {
+		var characterID, speaker any // NULL for stage directions
+		if line.Speaker != "" {
+			id, err := getCharacterID(line.Speaker)
+			if err != nil {
+				return err
+			}
+			characterID = id
+			written := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(speakerPrefix.FindString(strings.TrimSpace(q.lines[i]))), ":"))
+			if written == "" {
+				written = line.Speaker
+			}
+			speaker = written
+			if err = addAlias(written, line.Speaker); err != nil {
+				return err
+			}
+		}
+
+		_, err := tx.Exec("INSERT INTO lines (quote_id, position, character_id, speaker, text, raw) VALUES (?, ?, ?, ?, ?, ?)",
+			q.id, i, characterID, speaker, line.Text, q.lines[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := tx.Exec("INSERT INTO quotes_fts (quote_id, speakers, text) VALUES (?, ?, ?)",
+		q.id, strings.Join(doc.Speakers, ", "), strings.Join(q.lines, "\n"))
+	return err
+}
+
+// writePlots inserts the plot of every episode known to futurama, from Wikipedia
+func writePlots(tx *sql.Tx) error {
+	for i, season := range getSeries() {
+		for x, title := range season.episodes {
+			var episodeID int64
+			err := tx.QueryRow("SELECT id FROM episodes WHERE season = ? AND number = ?", i+1, x+1).Scan(&episodeID)
+			if err != nil {
+				return err
+			}
+
+			wikipediaTitle := getWikipediaTitle(title)
+			plot, err := getEpisodePlot(wikipediaTitle)
+			if err != nil { // no plot section, the episode is kept without one
+				continue
+			}
+			for position, text := range plot {
+				_, err = tx.Exec("INSERT INTO plots (episode_id, position, text, source) VALUES (?, ?, ?, ?)",
+					episodeID, position, text, wikipedia.pageURL(wikipediaTitle))
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
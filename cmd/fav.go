@@ -0,0 +1,31 @@
+/*
+Copyright © 2023 Aric Hansen <aric.p.hansen@gmail.com>
+*/
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var FavoritesFile string
+
+var favCmd = &cobra.Command{
+	Use:   "fav",
+	Short: "Manage a personal collection of favorite quotes",
+	Long: `Manage a personal collection of favorite quotes, identified by the IDs printed with every quote.
+
+Favorites are stored in the futurama config directory (e.g. ~/.config/futurama/favorites.json).
+Use --file to work with a collection stored anywhere else, such as a shared file in a repo.`,
+	Example: `  futurama fav add s01e01-1a2b3c4d --tag classic --note "Fry's first day"
+  futurama fav list --tag classic
+  futurama fav random
+  futurama fav rm s01e01-1a2b3c4d
+  futurama fav export -o favorites.json
+  futurama fav import --file ./wall-of-fame.json favorites.json`,
+	Args: cobra.ExactArgs(1),
+}
+
+func init() {
+	rootCmd.AddCommand(favCmd)
+	favCmd.PersistentFlags().StringVarP(&FavoritesFile, "file", "f", "", "Path of the favorites collection (default is the futurama config directory)")
+}
@@ -0,0 +1,63 @@
+/*
+Copyright © 2023 Aric Hansen <aric.p.hansen@gmail.com>
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var FavoriteTags []string
+var FavoriteNote string
+
+var favAddCmd = &cobra.Command{
+	Use:   "add <id>",
+	Short: "Add a quote to favorites",
+	Long:  "Add a quote to favorites, or add tags and a note to a quote that is already a favorite",
+	Example: `  futurama fav add s01e01-1a2b3c4d
+  futurama fav add s01e01-1a2b3c4d --tag classic --tag fry --note "Fry's first day"`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := addFavorite(args[0])
+		if err != nil {
+			fmt.Println(err)
+		}
+	},
+}
+
+func init() {
+	favCmd.AddCommand(favAddCmd)
+	favAddCmd.Flags().StringArrayVarP(&FavoriteTags, "tag", "t", []string{}, "Tag for the quote (repeatable)")
+	favAddCmd.Flags().StringVarP(&FavoriteNote, "note", "n", "", "Note for the quote")
+}
+
+func addFavorite(id string) error {
+	collection, err := loadFavorites(FavoritesFile)
+	if err != nil {
+		return err
+	}
+
+	var fav Favorite
+	if i := collection.find(id); i != -1 {
+		fav = collection.Favorites[i]
+	} else {
+		q, err := getQuoteByID(id)
+		if err != nil {
+			return err
+		}
+		fav = newFavorite(q)
+	}
+	fav.Tags = FavoriteTags
+	fav.Note = FavoriteNote
+
+	collection.merge(fav)
+	err = saveFavorites(FavoritesFile, collection)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Added " + fav.ID + " to favorites.")
+	return nil
+}
@@ -0,0 +1,54 @@
+/*
+Copyright © 2023 Aric Hansen <aric.p.hansen@gmail.com>
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var FavoritesExportFile string
+
+var favExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export favorite quotes to a JSON file",
+	Long:  "Export favorite quotes to a JSON file, or to stdout if no output file is provided",
+	Example: `  futurama fav export
+  futurama fav export -o favorites.json
+  futurama fav export --tag classic -o classics.json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		err := exportFavorites()
+		if err != nil {
+			fmt.Println(err)
+		}
+	},
+}
+
+func init() {
+	favCmd.AddCommand(favExportCmd)
+	favExportCmd.Flags().StringVarP(&FavoritesExportFile, "output-file", "o", "", "Path of the exported JSON file")
+	favExportCmd.Flags().StringVarP(&FavoriteTag, "tag", "t", "", "Only export quotes with this tag")
+}
+
+func exportFavorites() error {
+	collection, err := loadFavorites(FavoritesFile)
+	if err != nil {
+		return err
+	}
+	collection.Favorites = collection.withTag(FavoriteTag)
+
+	if FavoritesExportFile != "" {
+		return saveFavorites(FavoritesExportFile, collection)
+	}
+
+	data, err := json.MarshalIndent(collection, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+
+	return nil
+}
@@ -0,0 +1,63 @@
+/*
+Copyright © 2023 Aric Hansen <aric.p.hansen@gmail.com>
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var favImportCmd = &cobra.Command{
+	Use:   "import <json file>",
+	Short: "Import favorite quotes from a JSON file",
+	Long: `Import favorite quotes from a JSON file created with 'futurama fav export'.
+Quotes that are already favorites gain the imported tags, and their note is replaced by the imported note if it has one.`,
+	Example: `  futurama fav import favorites.json
+  futurama fav import --file ./wall-of-fame.json favorites.json`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := importFavorites(args[0])
+		if err != nil {
+			fmt.Println(err)
+		}
+	},
+}
+
+func init() {
+	favCmd.AddCommand(favImportCmd)
+}
+
+func importFavorites(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return errors.New("Unable to read " + path + ".")
+	}
+
+	imported, err := loadFavorites(path)
+	if err != nil {
+		return err
+	}
+
+	collection, err := loadFavorites(FavoritesFile)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range imported.Favorites {
+		if _, _, _, err := parseQuoteID(f.ID); err != nil || len(f.Lines) == 0 {
+			return errors.New("Invalid favorite in " + path + ": " + f.ID)
+		}
+		collection.merge(f)
+	}
+
+	err = saveFavorites(FavoritesFile, collection)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Imported %d favorites.\n", len(imported.Favorites))
+	return nil
+}
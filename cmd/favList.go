@@ -0,0 +1,53 @@
+/*
+Copyright © 2023 Aric Hansen <aric.p.hansen@gmail.com>
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var FavoriteTag string
+
+var favListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List favorite quotes",
+	Long:  "List favorite quotes, optionally only those with a tag",
+	Example: `  futurama fav list
+  futurama fav list --tag classic`,
+	Run: func(cmd *cobra.Command, args []string) {
+		err := listFavorites()
+		if err != nil {
+			fmt.Println(err)
+		}
+	},
+}
+
+func init() {
+	favCmd.AddCommand(favListCmd)
+	favListCmd.Flags().StringVarP(&FavoriteTag, "tag", "t", "", "Only list quotes with this tag")
+}
+
+func listFavorites() error {
+	collection, err := loadFavorites(FavoritesFile)
+	if err != nil {
+		return err
+	}
+
+	favorites := collection.withTag(FavoriteTag)
+	if len(favorites) == 0 {
+		fmt.Println("No favorites found.")
+		return nil
+	}
+
+	for i, f := range favorites {
+		if i > 0 {
+			fmt.Println("----")
+		}
+		printFavorite(f)
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+/*
+Copyright © 2023 Aric Hansen <aric.p.hansen@gmail.com>
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var favRandomCmd = &cobra.Command{
+	Use:   "random",
+	Short: "Get a random favorite quote",
+	Long:  "Get a random favorite quote, optionally only from those with a tag",
+	Example: `  futurama fav random
+  futurama fav random --tag classic`,
+	Run: func(cmd *cobra.Command, args []string) {
+		err := printRandomFavorite()
+		if err != nil {
+			fmt.Println(err)
+		}
+	},
+}
+
+func init() {
+	favCmd.AddCommand(favRandomCmd)
+	favRandomCmd.Flags().StringVarP(&FavoriteTag, "tag", "t", "", "Only pick from quotes with this tag")
+}
+
+func printRandomFavorite() error {
+	collection, err := loadFavorites(FavoritesFile)
+	if err != nil {
+		return err
+	}
+
+	favorites := collection.withTag(FavoriteTag)
+	if len(favorites) == 0 {
+		return errors.New("No favorites found.")
+	}
+
+	printFavorite(favorites[randomIndex(len(favorites)-1)])
+	return nil
+}
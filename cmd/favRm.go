@@ -0,0 +1,50 @@
+/*
+Copyright © 2023 Aric Hansen <aric.p.hansen@gmail.com>
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var favRmCmd = &cobra.Command{
+	Use:     "rm <id>",
+	Short:   "Remove a quote from favorites",
+	Long:    "Remove a quote from favorites",
+	Example: `  futurama fav rm s01e01-1a2b3c4d`,
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := removeFavorite(args[0])
+		if err != nil {
+			fmt.Println(err)
+		}
+	},
+}
+
+func init() {
+	favCmd.AddCommand(favRmCmd)
+}
+
+func removeFavorite(id string) error {
+	collection, err := loadFavorites(FavoritesFile)
+	if err != nil {
+		return err
+	}
+
+	i := collection.find(id)
+	if i == -1 {
+		return errors.New("Quote " + id + " is not in favorites.")
+	}
+	collection.Favorites = append(collection.Favorites[:i], collection.Favorites[i+1:]...)
+
+	err = saveFavorites(FavoritesFile, collection)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Removed " + id + " from favorites.")
+	return nil
+}
@@ -0,0 +1,165 @@
+/*
+Copyright © 2023 Aric Hansen <aric.p.hansen@gmail.com>
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const favoritesVersion = 1
+
+// FavoriteCollection is the JSON document stored in a favorites file.
+// Collections can be shared (e.g. a team "wall of fame" checked into a repo) and loaded with --file.
+type FavoriteCollection struct {
+	Version   int        `json:"version"`
+	Favorites []Favorite `json:"favorites"`
+}
+
+// Favorite keeps a copy of the quote so collections can be listed without contacting WikiQuote
+type Favorite struct {
+	ID         string    `json:"id"`
+	Season     int       `json:"season"`
+	Episode    string    `json:"episode"`
+	Characters []string  `json:"characters"`
+	Lines      []string  `json:"lines"`
+	Speakers   []string  `json:"speakers,omitempty"`
+	Tags       []string  `json:"tags,omitempty"`
+	Note       string    `json:"note,omitempty"`
+	Added      time.Time `json:"added"`
+}
+
+// getFavoritesPath returns the user-defined collection path, or the default favorites file
+func getFavoritesPath(path string) (string, error) {
+	if path != "" {
+		return path, nil
+	}
+
+	return getDataPath("favorites.json")
+}
+
+func loadFavorites(path string) (FavoriteCollection, error) {
+	collection := FavoriteCollection{Version: favoritesVersion}
+
+	path, err := getFavoritesPath(path)
+	if err != nil {
+		return collection, err
+	}
+
+	err = readJSONFile(path, &collection)
+	if err != nil {
+		return collection, err
+	}
+
+	if collection.Version > favoritesVersion {
+		return collection, errors.New("The favorites file " + path + " was created by a newer version of futurama.")
+	}
+
+	return collection, nil
+}
+
+func saveFavorites(path string, collection FavoriteCollection) error {
+	path, err := getFavoritesPath(path)
+	if err != nil {
+		return err
+	}
+
+	collection.Version = favoritesVersion
+	return writeJSONFile(path, collection)
+}
+
+// find returns the index of the favorite with the given ID, or -1
+func (c FavoriteCollection) find(id string) int {
+	for i, f := range c.Favorites {
+		if strings.EqualFold(f.ID, id) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// merge adds a favorite, or adds its tags and note to an existing favorite with the same ID
+func (c *FavoriteCollection) merge(fav Favorite) {
+	i := c.find(fav.ID)
+	if i == -1 {
+		c.Favorites = append(c.Favorites, fav)
+		return
+	}
+
+	existing := &c.Favorites[i]
+	for _, tag := range fav.Tags {
+		if !existing.hasTag(tag) {
+			existing.Tags = append(existing.Tags, tag)
+		}
+	}
+	if fav.Note != "" {
+		existing.Note = fav.Note
+	}
+}
+
+// withTag returns the favorites that have the tag, or every favorite if the tag is empty
+func (c FavoriteCollection) withTag(tag string) []Favorite {
+	if tag == "" {
+		return c.Favorites
+	}
+
+	favorites := []Favorite{}
+	for _, f := range c.Favorites {
+		if f.hasTag(tag) {
+			favorites = append(favorites, f)
+		}
+	}
+
+	return favorites
+}
+
+func (f Favorite) hasTag(tag string) bool {
+	for _, t := range f.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func newFavorite(q Quote) Favorite {
+	return Favorite{
+		ID:         q.id,
+		Season:     q.season,
+		Episode:    q.episode,
+		Characters: q.characters,
+		Lines:      q.lines,
+		Speakers:   q.speakers,
+		Added:      time.Now().UTC(),
+	}
+}
+
+func (f Favorite) quote() Quote {
+	return Quote{
+		characters: f.Characters,
+		lines:      f.Lines,
+		speakers:   f.Speakers,
+		season:     f.Season,
+		episode:    f.Episode,
+		id:         f.ID,
+	}
+}
+
+// printFavorite prints a favorite quote followed by its tags and note
+func printFavorite(f Favorite) {
+	printQuote(f.quote())
+	if len(f.Tags) > 0 || f.Note != "" {
+		fmt.Println()
+	}
+	if len(f.Tags) > 0 {
+		printField("Tags", strings.Join(f.Tags, ", "))
+	}
+	if f.Note != "" {
+		printField("Note", f.Note)
+	}
+}
@@ -12,17 +12,17 @@ var getCmd = &cobra.Command{
 	Use:   "get",
 	Short: "Get quote or list of episodes",
 	Long: `Get a Futurama quote from:
-  - a random episode in a random season
-  - a random episode in a user-defined season
+  - the entire series
+  - a user-defined season
   - a user-defined episode
-  - a random episode in a random season from a user-defined character
+  - a user-defined character, searched across the entire series
   
 Get a list of episodes from:
   - a user-defined season
   - the entire series
   
 Get a list of supported character names to use with the 'get quote' command  `,
-	Example: `  futurama get quote (no flags = randomized season and episode)
+	Example: `  futurama get quote (no flags = random quote from the entire series)
   futurama get quote --episode "Space Pilot 3000"
   futurama get episodes --season 2
   futurama get episodes --all
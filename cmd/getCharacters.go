@@ -15,7 +15,10 @@ var charactersCmd = &cobra.Command{
 	Long:    "Get list of valid characters for passing into the 'get quotes' command",
 	Example: `  futurama get characters`,
 	Run: func(cmd *cobra.Command, args []string) {
-		listSupportedCharacters()
+		err := listSupportedCharacters()
+		if err != nil {
+			fmt.Println(err)
+		}
 	},
 }
 
@@ -23,11 +26,23 @@ func init() {
 	getCmd.AddCommand(charactersCmd)
 }
 
-func listSupportedCharacters() {
+func listSupportedCharacters() error {
 	supportedCharacters := getSupportedCharacters()
 
-	fmt.Println("Supported Characters:")
+	if isStructuredOutput() {
+		doc := CharacterListDocument{Characters: []CharacterDocument{}}
+		items := []any{}
+		for _, c := range supportedCharacters {
+			doc.Characters = append(doc.Characters, CharacterDocument{Name: c})
+			items = append(items, CharacterDocument{Name: c})
+		}
+		return writeDocument(doc, items)
+	}
+
+	fmt.Println(colorize(theme.Header, "Supported Characters:"))
 	for _, c := range supportedCharacters {
-		fmt.Println(c)
+		fmt.Println(colorize(theme.Characters[c], c))
 	}
+
+	return nil
 }
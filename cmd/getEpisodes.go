@@ -19,7 +19,8 @@ var episodesCmd = &cobra.Command{
 	Long:  "Get list of all episodes or only episodes for a given season",
 	Example: `  futurama get episodes (return all episodes if no flags provided)
   futurama get episodes --all
-  futurama get episodes --season 2`,
+  futurama get episodes --season 2
+  futurama get episodes --format '{{.Season}}x{{.Number}} {{.Title}}'`,
 	Run: func(cmd *cobra.Command, args []string) {
 		err := listEpisodes()
 		if err != nil {
@@ -34,18 +35,32 @@ func init() {
 	getCmd.AddCommand(episodesCmd)
 	episodesCmd.Flags().BoolVarP(&AllEpisodes, "all", "a", true, "Show episodes from all seasons")
 	episodesCmd.Flags().IntVarP(&SeasonNumber, "season", "s", 0, "Season number (1-7)")
+	addTemplateFlags(episodesCmd.Flags())
 	episodesCmd.MarkFlagsMutuallyExclusive("season", "all")
 }
 
 func listEpisodes() error {
+	err := validateTemplate()
+	if err != nil {
+		return err
+	}
+
 	if SeasonNumber != 0 { // if season provided, turn off default -a flag
 		AllEpisodes = false
 	}
 
 	series := getSeries()
+	if isStructuredOutput() {
+		if !AllEpisodes && (SeasonNumber < 1 || SeasonNumber > 7) {
+			return errors.New("Invalid season number")
+		}
+		return writeEpisodes(series)
+	}
+
 	if AllEpisodes {
+		defer startPager()()
 		for _, season := range series {
-			fmt.Println("#### " + season.name + " ####")
+			fmt.Println(colorize(theme.Header, "#### "+season.name+" ####"))
 			for _, ep := range season.episodes {
 				fmt.Print(ep + "\n")
 			}
@@ -54,7 +69,7 @@ func listEpisodes() error {
 		return nil
 	} else {
 		if SeasonNumber > 0 && SeasonNumber < 8 {
-			fmt.Println("#### " + series[SeasonNumber-1].name + " ####")
+			fmt.Println(colorize(theme.Header, "#### "+series[SeasonNumber-1].name+" ####"))
 			for _, ep := range series[SeasonNumber-1].episodes {
 				fmt.Print(ep + "\n")
 			}
@@ -64,3 +79,23 @@ func listEpisodes() error {
 		}
 	}
 }
+
+// writeEpisodes prints the listed seasons in the structured --output format
+func writeEpisodes(series [7]SeasonEpisodes) error {
+	doc := EpisodeListDocument{Seasons: []SeasonDocument{}}
+	items := []any{}
+	for i, season := range series {
+		if !AllEpisodes && i+1 != SeasonNumber {
+			continue
+		}
+		seasonDoc := SeasonDocument{Number: i + 1, Name: season.name, Episodes: []EpisodeDocument{}}
+		for x, ep := range season.episodes {
+			epDoc := EpisodeDocument{Season: i + 1, Number: x + 1, Title: ep}
+			seasonDoc.Episodes = append(seasonDoc.Episodes, epDoc)
+			items = append(items, epDoc)
+		}
+		doc.Seasons = append(doc.Seasons, seasonDoc)
+	}
+
+	return writeDocument(doc, items)
+}
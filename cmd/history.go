@@ -0,0 +1,26 @@
+/*
+Copyright © 2023 Aric Hansen <aric.p.hansen@gmail.com>
+*/
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Manage the history of recently shown quotes",
+	Long: `Manage the history of recently shown quotes.
+
+'get quote' remembers the last ` + strconv.Itoa(historySize) + ` random quotes it showed and skips them,
+until every eligible quote has been shown. Use 'get quote --no-history' to bypass it.`,
+	Example: `  futurama history list
+  futurama history clear`,
+	Args: cobra.ExactArgs(1),
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+}
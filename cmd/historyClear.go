@@ -0,0 +1,29 @@
+/*
+Copyright © 2023 Aric Hansen <aric.p.hansen@gmail.com>
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var historyClearCmd = &cobra.Command{
+	Use:     "clear",
+	Short:   "Forget recently shown quotes",
+	Long:    "Forget recently shown quotes, so any quote can be picked again",
+	Example: `  futurama history clear`,
+	Run: func(cmd *cobra.Command, args []string) {
+		err := saveHistory(QuoteHistory{})
+		if err != nil {
+			fmt.Println(err)
+		} else {
+			fmt.Println("Quote history cleared.")
+		}
+	},
+}
+
+func init() {
+	historyCmd.AddCommand(historyClearCmd)
+}
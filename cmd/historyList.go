@@ -0,0 +1,46 @@
+/*
+Copyright © 2023 Aric Hansen <aric.p.hansen@gmail.com>
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var historyListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List recently shown quotes, newest first",
+	Long:    "List the IDs of recently shown quotes, newest first",
+	Example: `  futurama history list`,
+	Run: func(cmd *cobra.Command, args []string) {
+		err := listHistory()
+		if err != nil {
+			fmt.Println(err)
+		}
+	},
+}
+
+func init() {
+	historyCmd.AddCommand(historyListCmd)
+}
+
+func listHistory() error {
+	history, err := loadHistory()
+	if err != nil {
+		return err
+	}
+
+	if len(history.Entries) == 0 {
+		fmt.Println("No quotes in history.")
+		return nil
+	}
+
+	for i := len(history.Entries) - 1; i >= 0; i-- {
+		e := history.Entries[i]
+		fmt.Println(e.Shown.Local().Format("2006-01-02 15:04") + "  " + e.ID)
+	}
+
+	return nil
+}
@@ -0,0 +1,210 @@
+/*
+Copyright © 2023 Aric Hansen <aric.p.hansen@gmail.com>
+*/
+package cmd
+
+import (
+	"errors"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// names of the fields of the INFO section, for the describe episode --fields flag
+const (
+	fieldSeason         = "season"
+	fieldEpisode        = "episode"
+	fieldTitle          = "title"
+	fieldDirectors      = "directors"
+	fieldWriters        = "writers"
+	fieldProductionCode = "production-code"
+	fieldAirDate        = "air-date"
+	fieldGuests         = "guests"
+	fieldOpeningCaption = "opening-caption"
+	fieldOpeningCartoon = "opening-cartoon"
+)
+
+var episodeFields = []string{
+	fieldSeason, fieldEpisode, fieldTitle, fieldDirectors, fieldWriters, fieldProductionCode,
+	fieldAirDate, fieldGuests, fieldOpeningCaption, fieldOpeningCartoon,
+}
+
+// fields parsed from the infobox, which require retrieving the lead of the Wikipedia page
+var infoboxFields = episodeFields[3:]
+
+// EpisodeInfo holds the fields of the infobox of an episode's Wikipedia page
+type EpisodeInfo struct {
+	Directors      []string
+	Writers        []string
+	ProductionCode string
+	AirDate        string
+	Guests         []string // e.g. "Dick Clark as himself"
+	OpeningCaption string
+	OpeningCartoon string
+}
+
+// infoboxLabels maps the (lowercase) labels of the infobox rows to the fields they hold
+var infoboxLabels = map[string]string{
+	"directed by":         fieldDirectors,
+	"written by":          fieldWriters,
+	"production code":     fieldProductionCode,
+	"original air date":   fieldAirDate,
+	"original release":    fieldAirDate,
+	"guest appearance":    fieldGuests,
+	"guest appearances":   fieldGuests,
+	"guest appearance(s)": fieldGuests,
+	"opening caption":     fieldOpeningCaption,
+	"opening cartoon":     fieldOpeningCartoon,
+}
+
+// getDescribeFields parses the --fields flag into the set of selected fields
+func getDescribeFields(flag string) (map[string]bool, error) {
+	selected := map[string]bool{}
+	if strings.TrimSpace(flag) == "" {
+		for _, f := range episodeFields {
+			selected[f] = true
+		}
+		return selected, nil
+	}
+
+	for _, f := range strings.Split(flag, ",") {
+		f = strings.ToLower(strings.TrimSpace(f))
+		valid := false
+		for _, name := range episodeFields {
+			valid = valid || f == name
+		}
+		if !valid {
+			return nil, errors.New("Invalid field '" + f + "'. Please select from: " + strings.Join(episodeFields, ", ") + ".")
+		}
+		selected[f] = true
+	}
+
+	return selected, nil
+}
+
+// hasInfoboxField reports whether any of the selected fields comes from the infobox
+func hasInfoboxField(selected map[string]bool) bool {
+	for _, f := range infoboxFields {
+		if selected[f] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getEpisodeInfo retrieves the infobox of an episode's Wikipedia page
+func getEpisodeInfo(title string) (EpisodeInfo, error) {
+	page, err := wikipedia.getLead(title)
+	if err != nil {
+		return EpisodeInfo{}, err
+	}
+
+	doc, err := html.Parse(strings.NewReader(page.html))
+	if err != nil {
+		return EpisodeInfo{}, errors.New("Error parsing Wikipedia page: " + err.Error())
+	}
+
+	return parseInfobox(doc), nil
+}
+
+// parseInfobox extracts the fields of the first infobox of a page. Rows are either a label (th) and its data (td),
+// or a header row (e.g. "Guest appearances") followed by a full-width data row.
+func parseInfobox(doc *html.Node) EpisodeInfo {
+	info := EpisodeInfo{}
+	table := findNode(doc, func(n *html.Node) bool { return n.DataAtom == atom.Table && hasClass(n, "infobox") })
+	if table == nil {
+		return info
+	}
+
+	header := ""
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.DataAtom != atom.Tr {
+			for c := n.FirstChild; c != nil; c = c.NextSibling {
+				if c.DataAtom != atom.Table { // skip nested tables
+					walk(c)
+				}
+			}
+			return
+		}
+
+		label := header
+		var data *html.Node
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			switch c.DataAtom {
+			case atom.Th:
+				label = getVisibleText(c)
+			case atom.Td:
+				data = c
+			}
+		}
+		if data == nil { // header row, its data is on the next row
+			header = label
+			return
+		}
+		header = ""
+
+		values := getInfoboxValues(data)
+		if len(values) == 0 {
+			return
+		}
+		switch infoboxLabels[strings.ToLower(strings.Join(strings.Fields(label), " "))] {
+		case fieldDirectors:
+			info.Directors = values
+		case fieldWriters:
+			info.Writers = values
+		case fieldProductionCode:
+			info.ProductionCode = strings.Join(values, " ")
+		case fieldAirDate:
+			info.AirDate = strings.Join(values, " ")
+		case fieldGuests:
+			info.Guests = values
+		case fieldOpeningCaption:
+			info.OpeningCaption = strings.Join(values, " ")
+		case fieldOpeningCartoon:
+			info.OpeningCartoon = strings.Join(values, " ")
+		}
+	}
+	walk(table)
+
+	return info
+}
+
+// getInfoboxValues returns the lines of an infobox cell, which are separated by <br> or list items
+func getInfoboxValues(n *html.Node) []string {
+	values := []string{}
+	var b strings.Builder
+	endValue := func() {
+		if value := strings.Join(strings.Fields(b.String()), " "); value != "" {
+			values = append(values, value)
+		}
+		b.Reset()
+	}
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			b.WriteString(n.Data)
+			return
+		}
+		if isHiddenText(n) {
+			return
+		}
+		switch n.DataAtom {
+		case atom.Br:
+			endValue()
+		case atom.Li, atom.P, atom.Div:
+			endValue()
+			defer endValue()
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	endValue()
+
+	return values
+}
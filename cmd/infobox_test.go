@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func parseTestInfobox(t *testing.T, page string) EpisodeInfo {
+	t.Helper()
+	doc, err := html.Parse(strings.NewReader(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return parseInfobox(doc)
+}
+
+func TestParseInfobox(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want EpisodeInfo
+	}{
+		{
+			name: "label and data rows",
+			html: `<table class="infobox vevent"><tbody>
+<tr><th colspan="2" class="infobox-above">"Space Pilot 3000"</th></tr>
+<tr><th scope="row" class="infobox-label">Episode no.</th><td class="infobox-data">Season 1<br>Episode 1</td></tr>
+<tr><th scope="row" class="infobox-label">Directed by</th><td class="infobox-data"><a>Rich Moore</a></td></tr>
+<tr><th scope="row" class="infobox-label">Written by</th><td class="infobox-data"><a>David X. Cohen</a><sup class="reference"><a>[1]</a></sup></td></tr>
+<tr><th scope="row" class="infobox-label">Production code</th><td class="infobox-data">1ACV01</td></tr>
+<tr><th colspan="2" class="infobox-header">Episode features</th></tr>
+<tr><th scope="row" class="infobox-label">Opening caption</th><td class="infobox-data">Broadcast in Technicolor</td></tr>
+<tr><th scope="row" class="infobox-label">Opening cartoon</th><td class="infobox-data">"<a>Steamboat Willie</a>" (1928)</td></tr>
+</tbody></table>`,
+			want: EpisodeInfo{
+				Directors:      []string{"Rich Moore"},
+				Writers:        []string{"David X. Cohen"},
+				ProductionCode: "1ACV01",
+				OpeningCaption: "Broadcast in Technicolor",
+				OpeningCartoon: `"Steamboat Willie" (1928)`,
+			},
+		},
+		{
+			name: "guest appearance(s) header row",
+			html: `<table class="infobox"><tbody>
+<tr><th colspan="2" class="infobox-header">Guest appearance(s)</th></tr>
+<tr><td colspan="2" class="infobox-full-data"><a>Dick Clark</a> as himself</td></tr>
+<tr><th scope="row" class="infobox-label">Production code</th><td class="infobox-data">1ACV01</td></tr>
+</tbody></table>`,
+			want: EpisodeInfo{Guests: []string{"Dick Clark as himself"}, ProductionCode: "1ACV01"},
+		},
+		{
+			name: "values separated by br",
+			html: `<table class="infobox"><tbody>
+<tr><th>Directed by</th><td><a>Rich Moore</a><br><a>Gregg Vanzo</a></td></tr>
+</tbody></table>`,
+			want: EpisodeInfo{Directors: []string{"Rich Moore", "Gregg Vanzo"}},
+		},
+		{
+			name: "values separated by li",
+			html: `<table class="infobox"><tbody>
+<tr><th colspan="2">Guest appearances</th></tr>
+<tr><td colspan="2"><div class="plainlist"><ul><li><a>Dick Clark</a> as himself</li><li><a>Leonard Nimoy</a> as himself</li></ul></div></td></tr>
+</tbody></table>`,
+			want: EpisodeInfo{Guests: []string{"Dick Clark as himself", "Leonard Nimoy as himself"}},
+		},
+		{
+			name: "hidden start date",
+			html: `<table class="infobox"><tbody>
+<tr><th>Original air date</th><td>March 28, 1999<span style="display:none">&#160;(<span class="bday dtstart published updated">1999-03-28</span>)</span></td></tr>
+</tbody></table>`,
+			want: EpisodeInfo{AirDate: "March 28, 1999"},
+		},
+		{
+			name: "nested table",
+			html: `<table class="infobox"><tbody>
+<tr><th>Directed by</th><td><a>Rich Moore</a></td></tr>
+<tr><td colspan="2"><table><tbody><tr><th>Written by</th><td>Not a writer</td></tr></tbody></table></td></tr>
+<tr><th>Production code</th><td>1ACV01</td></tr>
+</tbody></table>`,
+			want: EpisodeInfo{Directors: []string{"Rich Moore"}, ProductionCode: "1ACV01"},
+		},
+		{
+			name: "no infobox",
+			html: `<p>"Space Pilot 3000" is the first episode.</p><table class="wikitable"><tr><th>Directed by</th><td>Someone</td></tr></table>`,
+			want: EpisodeInfo{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseTestInfobox(t, tt.html)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetDescribeFields(t *testing.T) {
+	all, err := getDescribeFields("")
+	if err != nil || len(all) != len(episodeFields) {
+		t.Errorf("got %v (%v), want every field", all, err)
+	}
+
+	selected, err := getDescribeFields("title, Air-Date")
+	if err != nil || !reflect.DeepEqual(selected, map[string]bool{fieldTitle: true, fieldAirDate: true}) {
+		t.Errorf("got %v (%v), want title and air-date", selected, err)
+	}
+	if hasInfoboxField(map[string]bool{fieldSeason: true, fieldTitle: true}) {
+		t.Error("season and title should not require the infobox")
+	}
+
+	if _, err = getDescribeFields("title,bogus"); err == nil {
+		t.Error("got no error for an invalid field")
+	}
+}
+
+func TestNewDescriptionDocumentFields(t *testing.T) {
+	SeasonIndex, EpisodeIndex, DescribeEpisodeName = 1, 1, "Space Pilot 3000"
+	info := EpisodeInfo{Directors: []string{"Rich Moore"}, AirDate: "March 28, 1999"}
+
+	doc := newDescriptionDocument([]string{}, info, map[string]bool{fieldAirDate: true}, DescribeEpisodeName)
+	if doc.Season != 0 || doc.Episode != 0 || doc.Title != "" || doc.Directors != nil {
+		t.Errorf("got unselected fields in %+v", doc)
+	}
+	if doc.AirDate != info.AirDate {
+		t.Errorf("got air date %q, want %q", doc.AirDate, info.AirDate)
+	}
+}
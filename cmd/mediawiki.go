@@ -0,0 +1,215 @@
+/*
+Copyright © 2023 Aric Hansen <aric.p.hansen@gmail.com>
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+// mediaWiki is a client for the API of a MediaWiki site (e.g. WikiQuote or Wikipedia)
+type mediaWiki struct {
+	name string // used in error messages
+	host string
+}
+
+var wikiquote = mediaWiki{name: "WikiQuote", host: "en.wikiquote.org"}
+var wikipedia = mediaWiki{name: "Wikipedia", host: "en.wikipedia.org"}
+
+// wikiPage is the rendered HTML of a page, or of one of its sections
+type wikiPage struct {
+	title string // after following redirects
+	html  string
+}
+
+// wikiSection is a section listed by action=parse
+type wikiSection struct {
+	Line   string `json:"line"`   // title of the section, as HTML
+	Anchor string `json:"anchor"` // id of the section heading
+	Index  string `json:"index"`  // "T-1" etc. for sections of transcluded templates
+	Level  string `json:"level"`
+}
+
+// wikiParseResponse is the response of action=parse, with formatversion=2
+type wikiParseResponse struct {
+	Parse struct {
+		Title    string        `json:"title"`
+		PageID   int           `json:"pageid"`
+		Text     string        `json:"text"`
+		Sections []wikiSection `json:"sections"`
+	} `json:"parse"`
+	Error *struct {
+		Code string `json:"code"`
+		Info string `json:"info"`
+	} `json:"error"`
+}
+
+// characters MediaWiki leaves as is in page URLs, besides letters and digits
+const wikiURLSafe = "-_.~;@$!*(),/:"
+
+// encodeWikiTitle encodes a page title as MediaWiki does in URLs (e.g. "Bender's Game" as "Bender%27s_Game")
+func encodeWikiTitle(title string) string {
+	var b strings.Builder
+	for _, c := range []byte(strings.ReplaceAll(strings.TrimSpace(title), " ", "_")) {
+		if 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || strings.IndexByte(wikiURLSafe, c) != -1 {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+
+	return b.String()
+}
+
+// pageURL returns the URL of a page, to link to it
+func (w mediaWiki) pageURL(title string) string {
+	return "https://" + w.host + "/wiki/" + encodeWikiTitle(title)
+}
+
+// getPage retrieves the rendered HTML of a whole page, following redirects
+func (w mediaWiki) getPage(title string) (wikiPage, error) {
+	resp, err := w.parse(url.Values{"page": {title}, "prop": {"text"}})
+	if err != nil {
+		return wikiPage{}, err
+	}
+
+	return wikiPage{title: resp.Parse.Title, html: resp.Parse.Text}, nil
+}
+
+// getLead retrieves the rendered HTML of the lead section of a page (with its infobox), following redirects
+func (w mediaWiki) getLead(title string) (wikiPage, error) {
+	resp, err := w.parse(url.Values{"page": {title}, "section": {"0"}, "prop": {"text"}})
+	if err != nil {
+		return wikiPage{}, err
+	}
+
+	return wikiPage{title: resp.Parse.Title, html: resp.Parse.Text}, nil
+}
+
+// getSection retrieves the rendered HTML of the first section of a page matching one of names
+// (e.g. "Plot"), heading included, following redirects. Names are compared case-insensitively.
+func (w mediaWiki) getSection(title string, names ...string) (wikiPage, error) {
+	if len(names) == 0 {
+		return wikiPage{}, errors.New("No section name was given to look up on the " + w.name + " page of " + title + ".")
+	}
+
+	resp, err := w.parse(url.Values{"page": {title}, "prop": {"sections"}})
+	if err != nil {
+		return wikiPage{}, err
+	}
+
+	index := ""
+	for _, name := range names {
+		for _, s := range resp.Parse.Sections {
+			if strings.HasPrefix(s.Index, "T-") {
+				continue
+			}
+			if strings.EqualFold(getSectionName(s), name) || strings.EqualFold(s.Anchor, strings.ReplaceAll(name, " ", "_")) {
+				index = s.Index
+				break
+			}
+		}
+		if index != "" {
+			break
+		}
+	}
+	if index == "" {
+		return wikiPage{}, fmt.Errorf("No %s section was found on the %s page of %s.", names[0], w.name, resp.Parse.Title)
+	}
+
+	// the page id keeps the section index on the page the redirects led to
+	section, err := w.parse(url.Values{"pageid": {fmt.Sprint(resp.Parse.PageID)}, "section": {index}, "prop": {"text"}})
+	if err != nil {
+		return wikiPage{}, err
+	}
+
+	return wikiPage{title: resp.Parse.Title, html: section.Parse.Text}, nil
+}
+
+// getSectionName returns the plain text title of a section, without its markup (e.g. <i>)
+func getSectionName(s wikiSection) string {
+	nodes, err := html.ParseFragment(strings.NewReader(s.Line), nil)
+	if err != nil {
+		return s.Line
+	}
+
+	name := ""
+	for _, n := range nodes {
+		name += getVisibleText(n)
+	}
+
+	return strings.TrimSpace(name)
+}
+
+// parse calls action=parse with params, following redirects
+func (w mediaWiki) parse(params url.Values) (wikiParseResponse, error) {
+	params.Set("action", "parse")
+	params.Set("format", "json")
+	params.Set("formatversion", "2")
+	params.Set("redirects", "1")
+
+	var resp wikiParseResponse
+	err := w.getJSON("https://"+w.host+"/w/api.php?"+params.Encode(), &resp)
+	if err != nil {
+		return resp, err
+	}
+	if resp.Error != nil {
+		if resp.Error.Code == "missingtitle" {
+			return resp, fmt.Errorf("The %s page %s does not exist.", w.name, params.Get("page"))
+		}
+		return resp, fmt.Errorf("%s API error: %s", w.name, resp.Error.Info)
+	}
+
+	return resp, nil
+}
+
+// number of attempts at each API request
+const wikiAttempts = 5
+
+// getJSON retrieves and decodes a JSON API response into v, retrying on failure
+func (w mediaWiki) getJSON(apiURL string, v any) error {
+	req, err := http.NewRequest(http.MethodGet, apiURL, nil)
+	if err != nil {
+		return err
+	}
+	// the Wikimedia API policy asks clients to identify themselves
+	req.Header.Set("User-Agent", "futurama/"+Version+" (https://github.com/aric-h/futurama)")
+
+	var resp *http.Response
+	for i := 0; i < wikiAttempts; i++ { // retry in case of bad response
+		if i > 0 {
+			time.Sleep(time.Duration(i-1) * time.Second)
+		}
+		resp, err = http.DefaultClient.Do(req)
+		if err == nil && resp.StatusCode == http.StatusOK {
+			break
+		}
+		if err == nil && i < wikiAttempts-1 { // the last response is reported below
+			resp.Body.Close()
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("Error fetching %s: %v", w.name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s response status code was %d", w.name, resp.StatusCode)
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(v)
+	if err != nil {
+		return errors.New("Error decoding " + w.name + " response: " + err.Error())
+	}
+
+	return nil
+}
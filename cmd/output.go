@@ -0,0 +1,260 @@
+/*
+Copyright © 2023 Aric Hansen <aric.p.hansen@gmail.com>
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var OutputFormat string
+
+// supported values for the --output flag
+const (
+	outputText   = "text"   // human-readable text (default)
+	outputJSON   = "json"   // a single JSON document
+	outputYAML   = "yaml"   // the same document as YAML
+	outputNDJSON = "ndjson" // one JSON object per line, one per quote, episode, character, etc.
+)
+
+func validateOutput() error {
+	for _, o := range []string{outputText, outputJSON, outputYAML, outputNDJSON} {
+		if OutputFormat == o {
+			return nil
+		}
+	}
+
+	return errors.New("Invalid output format. Please select one of: text, json, yaml, ndjson.")
+}
+
+// isStructuredOutput reports whether results are printed as documents (--output) or through a template (--format)
+func isStructuredOutput() bool {
+	return OutputFormat != outputText || isTemplateOutput()
+}
+
+// writeDocument prints a document in the structured --output format.
+// ndjson and templates print each of the items instead of the whole document.
+func writeDocument(doc any, items []any) error {
+	if isTemplateOutput() {
+		return writeTemplate(items)
+	}
+
+	switch OutputFormat {
+	case outputYAML:
+		encoder := yaml.NewEncoder(os.Stdout)
+		encoder.SetIndent(2)
+		err := encoder.Encode(doc)
+		if err != nil {
+			return err
+		}
+		return encoder.Close()
+	case outputNDJSON:
+		for _, item := range items {
+			data, err := json.Marshal(item)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+		}
+	default:
+		data, err := json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+	}
+
+	return nil
+}
+
+// QuoteListDocument is printed by 'get quote' (docs/schema/quotes.schema.json)
+type QuoteListDocument struct {
+	Quotes []QuoteDocument `json:"quotes" yaml:"quotes"`
+}
+
+type QuoteDocument struct {
+	ID       string                `json:"id" yaml:"id"`
+	Season   int                   `json:"season" yaml:"season"`
+	Episode  EpisodeDocument       `json:"episode" yaml:"episode"`
+	Speakers []string              `json:"speakers" yaml:"speakers"`
+	Lines    []QuoteLineDocument   `json:"lines" yaml:"lines"`
+	Source   string                `json:"source" yaml:"source"`
+	Context  *QuoteContextDocument `json:"context,omitempty" yaml:"context,omitempty"`
+}
+
+type QuoteLineDocument struct {
+	Speaker  string   `json:"speaker,omitempty" yaml:"speaker,omitempty"`   // first speaker of the line, empty for stage directions
+	Speakers []string `json:"speakers,omitempty" yaml:"speakers,omitempty"` // every speaker of the line, e.g. both in "Fry and Leela:"
+	Text     string   `json:"text" yaml:"text"`
+}
+
+// QuoteContextDocument holds the quotes around a quote requested with --context
+type QuoteContextDocument struct {
+	Before []QuoteDocument `json:"before" yaml:"before"`
+	After  []QuoteDocument `json:"after" yaml:"after"`
+}
+
+// EpisodeDocument identifies an episode; number is its position in the season (0 if unknown)
+type EpisodeDocument struct {
+	Season int    `json:"season,omitempty" yaml:"season,omitempty"`
+	Number int    `json:"number" yaml:"number"`
+	Title  string `json:"title" yaml:"title"`
+}
+
+// EpisodeListDocument is printed by 'get episodes' (docs/schema/episodes.schema.json)
+type EpisodeListDocument struct {
+	Seasons []SeasonDocument `json:"seasons" yaml:"seasons"`
+}
+
+type SeasonDocument struct {
+	Number   int               `json:"number" yaml:"number"`
+	Name     string            `json:"name" yaml:"name"`
+	Episodes []EpisodeDocument `json:"episodes" yaml:"episodes"`
+}
+
+// CharacterListDocument is printed by 'get characters' (docs/schema/characters.schema.json)
+type CharacterListDocument struct {
+	Characters []CharacterDocument `json:"characters" yaml:"characters"`
+}
+
+type CharacterDocument struct {
+	Name string `json:"name" yaml:"name"`
+}
+
+// DescriptionDocument is printed by 'describe episode' (docs/schema/description.schema.json)
+type DescriptionDocument struct {
+	Season         int      `json:"season,omitempty" yaml:"season,omitempty"`
+	Episode        int      `json:"episode,omitempty" yaml:"episode,omitempty"`
+	Title          string   `json:"title,omitempty" yaml:"title,omitempty"`
+	Directors      []string `json:"directors,omitempty" yaml:"directors,omitempty"`
+	Writers        []string `json:"writers,omitempty" yaml:"writers,omitempty"`
+	ProductionCode string   `json:"productionCode,omitempty" yaml:"productionCode,omitempty"`
+	AirDate        string   `json:"airDate,omitempty" yaml:"airDate,omitempty"`
+	Guests         []string `json:"guests,omitempty" yaml:"guests,omitempty"`
+	OpeningCaption string   `json:"openingCaption,omitempty" yaml:"openingCaption,omitempty"`
+	OpeningCartoon string   `json:"openingCartoon,omitempty" yaml:"openingCartoon,omitempty"`
+	Plot           []string `json:"plot" yaml:"plot"`
+	PlotError      string   `json:"plotError,omitempty" yaml:"plotError,omitempty"` // why the plot is empty
+	Source         string   `json:"source" yaml:"source"`
+	Links          []string `json:"links" yaml:"links"`
+}
+
+// VersionDocument is printed by 'version' (docs/schema/version.schema.json)
+type VersionDocument struct {
+	Version string `json:"version" yaml:"version"`
+}
+
+// speakerPrefix matches the "Fry:" at the start of a quote line
+var speakerPrefix = regexp.MustCompile(`^[^:\[\]]{1,60}:\s*`)
+
+// speakerSeparator splits the names of a line spoken together, e.g. "Fry and Leela" or "Fry, Leela & Bender"
+var speakerSeparator = regexp.MustCompile(`\s*(?:,|&|\band\b)\s*`)
+
+func newQuoteDocument(q Quote) QuoteDocument {
+	doc := QuoteDocument{
+		ID:     q.id,
+		Season: q.season,
+		Episode: EpisodeDocument{
+			Number: getEpisodeNumber(q.season, q.episode),
+			Title:  q.episode,
+		},
+		Speakers: q.characters,
+		Lines:    []QuoteLineDocument{},
+		Source:   getQuoteSourceURL(q),
+	}
+	if doc.Speakers == nil {
+		doc.Speakers = []string{}
+	}
+
+	for i, line := range q.lines {
+		speaker := ""
+		if i < len(q.speakers) {
+			speaker = q.speakers[i]
+		}
+		text := strings.TrimSpace(line)
+		if m := directedSpeakerPrefix.FindStringSubmatch(text); speaker != "" && !speakerPrefix.MatchString(text) && m != nil {
+			text = strings.TrimSpace(m[2]) + " " + text[len(m[0]):] // keep the directions, e.g. "[whispering] Yes."
+		} else if speaker != "" {
+			text = speakerPrefix.ReplaceAllString(text, "")
+		}
+		doc.Lines = append(doc.Lines, QuoteLineDocument{Speaker: speaker, Speakers: getLineSpeakers(q, i), Text: text})
+	}
+
+	return doc
+}
+
+// getLineSpeakers lists every character speaking a line of a quote, its first speaker first.
+// Names sharing the line (e.g. "Fry and Leela:") are kept if they are among the quote's characters.
+func getLineSpeakers(q Quote, i int) []string {
+	if i >= len(q.speakers) || q.speakers[i] == "" {
+		return nil
+	}
+
+	line := strings.TrimSpace(q.lines[i])
+	written := strings.TrimSuffix(strings.TrimSpace(speakerPrefix.FindString(line)), ":")
+	if m := directedSpeakerPrefix.FindStringSubmatch(line); written == "" && m != nil {
+		written = m[1]
+	}
+
+	speakers := []string{q.speakers[i]}
+	for _, name := range speakerSeparator.Split(strings.TrimSpace(written), -1) {
+		name = normalizeName(name)
+		included := false
+		for _, s := range speakers {
+			included = included || s == name
+		}
+		if !included && hasCharacter(q, name) {
+			speakers = append(speakers, name)
+		}
+	}
+
+	return speakers
+}
+
+// getQuoteSourceURL returns the WikiQuote page a quote was parsed from
+func getQuoteSourceURL(q Quote) string {
+	if n := getEpisodeNumber(q.season, q.episode); q.season == 5 && n != 0 {
+		return getFilmURL(getSeries()[4].episodes[n-1])
+	}
+
+	return getSeasonURL(q.season)
+}
+
+// writeQuotes prints quotes in the structured --output format, with their --context if requested
+func writeQuotes(quotes []Quote, withContext bool) error {
+	doc := QuoteListDocument{Quotes: []QuoteDocument{}}
+	items := []any{}
+	for _, q := range quotes {
+		qDoc := newQuoteDocument(q)
+		if withContext && QuoteContext > 0 {
+			context, start, selected, end, err := getQuoteContext(q)
+			if err != nil {
+				return err
+			}
+			if selected != -1 {
+				qDoc.Context = &QuoteContextDocument{Before: []QuoteDocument{}, After: []QuoteDocument{}}
+				for i := start; i < selected; i++ {
+					qDoc.Context.Before = append(qDoc.Context.Before, newQuoteDocument(context[i]))
+				}
+				for i := selected + 1; i < end; i++ {
+					qDoc.Context.After = append(qDoc.Context.After, newQuoteDocument(context[i]))
+				}
+			}
+		}
+		doc.Quotes = append(doc.Quotes, qDoc)
+		if isTemplateOutput() {
+			items = append(items, newQuoteTemplateData(qDoc))
+		} else {
+			items = append(items, qDoc)
+		}
+	}
+
+	return writeDocument(doc, items)
+}
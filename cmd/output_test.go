@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewQuoteDocumentLineSpeakers(t *testing.T) {
+	q := Quote{
+		lines: []string{
+			"Bender: Bite my shiny metal ass!",
+			"Fry and Leela: Yes.",
+			"[They leave.]",
+			"Professor Farnsworth, Fry & Bender [together]: Good news!",
+		},
+		speakers:   []string{"Bender", "Fry", "", "Prof. Farnsworth"},
+		characters: []string{"Bender", "Fry", "Leela", "Prof. Farnsworth"},
+	}
+
+	want := []QuoteLineDocument{
+		{Speaker: "Bender", Speakers: []string{"Bender"}, Text: "Bite my shiny metal ass!"},
+		{Speaker: "Fry", Speakers: []string{"Fry", "Leela"}, Text: "Yes."},
+		{Text: "[They leave.]"},
+		{Speaker: "Prof. Farnsworth", Speakers: []string{"Prof. Farnsworth", "Fry", "Bender"}, Text: "[together] Good news!"},
+	}
+	if got := newQuoteDocument(q).Lines; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,61 @@
+/*
+Copyright © 2023 Aric Hansen <aric.p.hansen@gmail.com>
+*/
+package cmd
+
+import (
+	"os"
+	"os/exec"
+
+	"golang.org/x/term"
+)
+
+var NoPager bool
+
+// startPager sends everything printed to stdout through $PAGER (or less) when stdout is a terminal.
+// The returned function must be called once printing is done, to wait for the pager to exit.
+func startPager() func() {
+	noop := func() {}
+	if NoPager || isStructuredOutput() || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return noop
+	}
+
+	pager := os.Getenv("PAGER")
+	if pager == "" {
+		if _, err := exec.LookPath("less"); err != nil {
+			return noop
+		}
+		pager = "less"
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		return noop
+	}
+
+	cmd := exec.Command("sh", "-c", pager)
+	cmd.Stdin = r
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = os.Environ()
+	if os.Getenv("LESS") == "" { // quit if the output fits on one screen, and keep colors
+		cmd.Env = append(cmd.Env, "LESS=FRX")
+	}
+
+	err = cmd.Start()
+	if err != nil {
+		r.Close()
+		w.Close()
+		return noop
+	}
+	r.Close()
+
+	stdout := os.Stdout
+	os.Stdout = w
+
+	return func() {
+		os.Stdout = stdout
+		w.Close()
+		cmd.Wait()
+	}
+}
@@ -0,0 +1,232 @@
+/*
+Copyright © 2023 Aric Hansen <aric.p.hansen@gmail.com>
+*/
+package cmd
+
+import (
+	"errors"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// names of the Plot section on Wikipedia episode pages
+var plotSectionNames = []string{"Plot", "Plot summary", "Synopsis"}
+
+// getEpisodePlot retrieves the paragraphs of the Plot section of an episode's Wikipedia page
+func getEpisodePlot(title string) ([]string, error) {
+	page, err := wikipedia.getSection(title, plotSectionNames...)
+	if err != nil {
+		return nil, err
+	}
+
+	return getPagePlot(page)
+}
+
+// getPagePlot parses the plot from the HTML of a page (or of its Plot section)
+func getPagePlot(page wikiPage) ([]string, error) {
+	doc, err := html.Parse(strings.NewReader(page.html))
+	if err != nil {
+		return nil, errors.New("Error parsing Wikipedia page: " + err.Error())
+	}
+
+	plot := parsePlot(doc)
+	if len(plot) == 0 {
+		return nil, errors.New("No plot was found on the Wikipedia page of " + page.title + ".")
+	}
+
+	return plot, nil
+}
+
+// parsePlot extracts the paragraphs (and subsection titles) of the Plot section of a Wikipedia page.
+// Two heading markups are supported:
+//   - <h2><span class="mw-headline" id="Plot">Plot</span></h2> (until 2024)
+//   - <div class="mw-heading mw-heading2"><h2 id="Plot">Plot</h2></div>
+//
+// The section ends at the next heading of the same level, or at the end of its parent
+// (e.g. a <section> element).
+func parsePlot(doc *html.Node) []string {
+	var title *html.Node
+	for _, name := range plotSectionNames {
+		id := strings.ReplaceAll(name, " ", "_")
+		if title = findNode(doc, func(n *html.Node) bool { return getAttr(n, "id") == id }); title != nil {
+			break
+		}
+	}
+	if title == nil {
+		return nil
+	}
+
+	heading := getHeading(title)
+	if heading == nil {
+		return nil
+	}
+	level := getHeadingLevel(heading)
+
+	plot, _ := getPlotParagraphs(heading.NextSibling, level)
+	return plot
+}
+
+// getPlotParagraphs collects the paragraphs and subsection titles from n and its next siblings,
+// descending into <section> elements, until a heading of the given level or higher.
+// It returns whether such a heading was reached.
+func getPlotParagraphs(n *html.Node, level int) ([]string, bool) {
+	plot := []string{}
+	for ; n != nil; n = n.NextSibling {
+		if n.Type != html.ElementNode {
+			continue
+		}
+		if n.DataAtom == atom.Section { // subsections are nested in sections on newer pages
+			paragraphs, end := getPlotParagraphs(n.FirstChild, level)
+			plot = append(plot, paragraphs...)
+			if end {
+				return plot, true
+			}
+			continue
+		}
+		if l := getHeadingLevel(n); l != 0 {
+			if l <= level { // end of the plot section
+				return plot, true
+			}
+			if text := getVisibleText(n); text != "" { // subsection title
+				plot = append(plot, text)
+			}
+			continue
+		}
+		if n.DataAtom == atom.P {
+			if text := getVisibleText(n); text != "" {
+				plot = append(plot, text)
+			}
+		}
+	}
+
+	return plot, false
+}
+
+// getHeading returns the outermost element of the heading a title belongs to:
+// the <div class="mw-heading"> around the <h2>, or the <h2> itself
+func getHeading(title *html.Node) *html.Node {
+	for n := title; n != nil; n = n.Parent {
+		if n.Parent != nil && hasClass(n.Parent, "mw-heading") {
+			return n.Parent
+		}
+		if getHeadingLevel(n) != 0 {
+			return n
+		}
+	}
+
+	return nil
+}
+
+// getHeadingLevel returns the level of a heading element (2 for <h2> or <div class="mw-heading mw-heading2">), or 0
+func getHeadingLevel(n *html.Node) int {
+	if n.Type != html.ElementNode {
+		return 0
+	}
+
+	switch n.DataAtom {
+	case atom.H1:
+		return 1
+	case atom.H2:
+		return 2
+	case atom.H3:
+		return 3
+	case atom.H4:
+		return 4
+	case atom.H5:
+		return 5
+	case atom.H6:
+		return 6
+	}
+
+	if hasClass(n, "mw-heading") {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if l := getHeadingLevel(c); l != 0 {
+				return l
+			}
+		}
+	}
+
+	return 0
+}
+
+// getVisibleText returns the text of an element as displayed in the article, without edit links, references, and styles
+func getVisibleText(n *html.Node) string {
+	var b strings.Builder
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			b.WriteString(n.Data)
+			return
+		}
+		if isHiddenText(n) {
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+
+	return strings.TrimSpace(b.String())
+}
+
+// isHiddenText reports whether an element holds text that isn't part of the article
+// (edit links, references, styles, and hidden microformats like the ISO date of {{Start date}})
+func isHiddenText(n *html.Node) bool {
+	if n.Type != html.ElementNode {
+		return false
+	}
+
+	return hasClass(n, "mw-editsection") || hasClass(n, "reference") || n.DataAtom == atom.Style || n.DataAtom == atom.Script ||
+		strings.Contains(strings.ReplaceAll(getAttr(n, "style"), " ", ""), "display:none")
+}
+
+// findNode returns the first node in document order that matches
+func findNode(n *html.Node, match func(n *html.Node) bool) *html.Node {
+	if match(n) {
+		return n
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if found := findNode(c, match); found != nil {
+			return found
+		}
+	}
+
+	return nil
+}
+
+// findNodes returns the elements of a type within n (n excluded), in document order, without descending into matches
+func findNodes(n *html.Node, a atom.Atom) []*html.Node {
+	nodes := []*html.Node{}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.DataAtom == a {
+			nodes = append(nodes, c)
+		} else {
+			nodes = append(nodes, findNodes(c, a)...)
+		}
+	}
+
+	return nodes
+}
+
+func getAttr(n *html.Node, key string) string {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+
+	return ""
+}
+
+func hasClass(n *html.Node, class string) bool {
+	for _, c := range strings.Fields(getAttr(n, "class")) {
+		if c == class {
+			return true
+		}
+	}
+
+	return false
+}
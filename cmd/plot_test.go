@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetPagePlot(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want []string
+	}{
+		{
+			name: "span headline",
+			html: `<p>Lead.</p>
+<h2><span class="mw-headline" id="Plot">Plot</span><span class="mw-editsection">[<a>edit</a>]</span></h2>
+<p>Fry is <b>frozen</b>.<sup class="reference"><a>[1]</a></sup></p>
+<h3><span class="mw-headline" id="Subplot">Subplot</span></h3>
+<p>Bender joins.</p>
+<h2><span class="mw-headline" id="Production">Production</span></h2>
+<p>Not the plot.</p>`,
+			want: []string{"Fry is frozen.", "Subplot", "Bender joins."},
+		},
+		{
+			name: "mw-heading",
+			html: `<p>Lead.</p>
+<div class="mw-heading mw-heading2"><h2 id="Plot">Plot</h2><span class="mw-editsection">[<a>edit</a>]</span></div>
+<p>Fry is frozen.</p>
+<div class="mw-heading mw-heading3"><h3 id="Subplot">Subplot</h3></div>
+<p>Bender joins.</p>
+<div class="mw-heading mw-heading2"><h2 id="Production">Production</h2></div>
+<p>Not the plot.</p>`,
+			want: []string{"Fry is frozen.", "Subplot", "Bender joins."},
+		},
+		{
+			name: "span headline in sections",
+			html: `<section><p>Lead.</p></section>
+<section><h2><span class="mw-headline" id="Plot">Plot</span></h2>
+<p>Fry is frozen.</p>
+<section><h3><span class="mw-headline" id="Subplot">Subplot</span></h3><p>Bender joins.</p></section></section>
+<section><h2><span class="mw-headline" id="Production">Production</span></h2><p>Not the plot.</p></section>`,
+			want: []string{"Fry is frozen.", "Subplot", "Bender joins."},
+		},
+		{
+			name: "mw-heading in sections",
+			html: `<section><p>Lead.</p></section>
+<section><div class="mw-heading mw-heading2"><h2 id="Plot">Plot</h2></div>
+<p>Fry is frozen.</p>
+<section><div class="mw-heading mw-heading3"><h3 id="Subplot">Subplot</h3></div><p>Bender joins.</p></section></section>
+<section><div class="mw-heading mw-heading2"><h2 id="Production">Production</h2></div><p>Not the plot.</p></section>`,
+			want: []string{"Fry is frozen.", "Subplot", "Bender joins."},
+		},
+		{
+			name: "plot summary",
+			html: `<div class="mw-heading mw-heading2"><h2 id="Plot_summary">Plot summary</h2></div>
+<p>Fry is frozen.</p>
+<div class="mw-heading mw-heading2"><h2 id="Reception">Reception</h2></div>`,
+			want: []string{"Fry is frozen."},
+		},
+		{
+			name: "synopsis",
+			html: `<h2><span class="mw-headline" id="Synopsis">Synopsis</span></h2>
+<p>Fry is frozen.</p>`,
+			want: []string{"Fry is frozen."},
+		},
+		{
+			name: "section only",
+			html: `<div class="mw-parser-output"><div class="mw-heading mw-heading2"><h2 id="Plot">Plot</h2></div><p>Fry is frozen.</p></div>`,
+			want: []string{"Fry is frozen."},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getPagePlot(wikiPage{title: "Space Pilot 3000", html: tt.html})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetPagePlotNotFound(t *testing.T) {
+	for _, html := range []string{
+		`<p>Lead.</p><div class="mw-heading mw-heading2"><h2 id="Production">Production</h2></div><p>Not the plot.</p>`,
+		`<div class="mw-heading mw-heading2"><h2 id="Plot">Plot</h2></div><div class="mw-heading mw-heading2"><h2 id="Production">Production</h2></div>`,
+	} {
+		_, err := getPagePlot(wikiPage{title: "Space Pilot 3000", html: html})
+		if err == nil || err.Error() != "No plot was found on the Wikipedia page of Space Pilot 3000." {
+			t.Errorf("got error %v, want no plot found", err)
+		}
+	}
+}
@@ -7,9 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"log"
 	"math/rand"
-	"net/http"
 	"strconv"
 	"strings"
 	"time"
@@ -18,6 +16,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
 )
 
 // vars for storing flag input
@@ -25,33 +24,99 @@ var QuoteSeason int
 var QuoteEpisode string
 var QuoteCharacter string
 var AllQuotes bool
+var DailyQuote bool
+var DailyDate string
+var DailyTimezone string
+var QuoteWeighting string
+var QuoteID string
+var FromFavorites bool
+var NoHistory bool
+var QuoteCount int
+var QuoteSort string
 
 // quoteCmd represents the quote command
 var quoteCmd = &cobra.Command{
 	Use:   "quote",
 	Short: "Get random Futurama quote",
 	Long: `Get a Futurama quote from:
-  - a random episode in a random season
-  - a random episode in a user-defined season
+  - the entire series
+  - a user-defined season
   - a user-defined episode
-  - a random episode in a random season from a user-defined character (use 'get characters' for assistance)
+  - a user-defined character, searched across the entire series (use 'get characters' for assistance)
+
+  The --season, --episode and --character flags can be combined to narrow the search
+  (e.g. a Bender quote from season 3).
   
-  Or get all quotes from a user-defined episode. `,
-	Example: `  futurama get-quote (no flags = randomized season and episode)
+  Or get all quotes from a user-defined episode and/or character.
+
+  Random quotes are weighted with --weighting:
+  - quote: every quote in the series (or season) is equally likely (default)
+  - episode: every episode is equally likely, then every quote in that episode
+  - season: every season is equally likely, then every episode, then every quote
+
+  Use --daily to get the quote of the day, which is the same for everyone on a given date. `,
+	Example: `  futurama get-quote (no flags = random quote from the entire series)
   futurama get quote --season 2
+  futurama get quote --weighting episode
   futurama get quote --episode "Space Pilot 3000"
   futurama get quote --character "Fry"
-  futurama get quote --all --episode "The Series Has Landed"`,
+  futurama get quote --all --episode "The Series Has Landed"
+  futurama get quote --character "Bender" --season 3
+  futurama get quote --all --character "Zoidberg" --episode "The Deep South"
+  futurama get quote --with Fry --with Leela --without Zoidberg
+  futurama get quote --only Bender --season 2
+  futurama get quote --max-chars 140 --single-line
+  futurama get quote --count 10 --season 4 --sort episode
+  futurama get quote --id s01e01-1a2b3c4d
+  futurama get quote --id s01e01-1a2b3c4d --context 2
+  futurama get quote --character Bender --bubble
+  futurama get quote --context 1 --style screenplay
+  futurama get quote --from-favorites --character Bender
+  futurama get quote --format '{{.Speaker}}: {{.Text}} — {{.Episode.Title}}'
+  futurama get quote --daily
+  futurama get quote --daily --date 2023-07-31 --tz "America/New_York"`,
 	Run: func(cmd *cobra.Command, args []string) {
 		err := validateInput(cmd.Flags())
 		if err != nil {
 			fmt.Println(err)
 			fmt.Println()
 			cmd.Help()
-		} else {
+		} else if QuoteID != "" {
+			q, err := getQuoteByID(QuoteID)
+			if err == nil {
+				err = printQuoteList([]Quote{q})
+			}
+			if err != nil {
+				fmt.Println(err)
+			}
+		} else if DailyQuote {
+			err = printDailyQuote()
+			if err != nil {
+				fmt.Println(err)
+			}
+		} else if AllQuotes {
+			defer startPager()()
+			err = printAllQuotes()
+			if err != nil {
+				fmt.Println(err)
+			}
+		} else if useSinglePage() {
 			randomize()
-			season := getQuotes()
-			printQuotes(season)
+			season, err := getQuotes()
+			if err == nil {
+				err = printQuotes(season)
+			}
+			if err != nil {
+				fmt.Println(err)
+			}
+		} else {
+			if QuoteCount > 1 {
+				defer startPager()()
+			}
+			err = printRandomQuote()
+			if err != nil {
+				fmt.Println(err)
+			}
 		}
 	},
 }
@@ -61,16 +126,119 @@ func init() {
 	quoteCmd.Flags().IntVarP(&QuoteSeason, "season", "s", 0, "Season number (1-7)")
 	quoteCmd.Flags().StringVarP(&QuoteEpisode, "episode", "e", "", "Episode name (use 'futurama get episodes' command for assistance)")
 	quoteCmd.Flags().StringVarP(&QuoteCharacter, "character", "c", "", "Character name (e.g. 'Fry', 'Bender')")
-	quoteCmd.Flags().BoolVarP(&AllQuotes, "all", "a", false, "Toggle for returning all quotes from an episode")
+	quoteCmd.Flags().BoolVarP(&AllQuotes, "all", "a", false, "Toggle for returning all quotes from an episode and/or character")
+	quoteCmd.Flags().StringVarP(&QuoteWeighting, "weighting", "w", weightQuote, "How random quotes are weighted (quote, episode, season)")
+	quoteCmd.Flags().IntVarP(&QuoteCount, "count", "n", 1, "Number of distinct random quotes to return")
+	quoteCmd.Flags().StringVar(&QuoteSort, "sort", sortShuffle, "Order of the quotes returned with --count (shuffle, episode)")
+	quoteCmd.Flags().IntVar(&QuoteContext, "context", 0, "Number of quotes to show before and after the selected quote")
+	quoteCmd.Flags().StringVar(&QuoteID, "id", "", "ID of a previously printed quote (e.g. 's03e05-1a2b3c4d')")
+	quoteCmd.Flags().BoolVar(&FromFavorites, "from-favorites", false, "Toggle for picking from favorite quotes instead of WikiQuote")
+	quoteCmd.Flags().StringVar(&FavoritesFile, "favorites-file", "", "Path of the favorites collection used with --from-favorites (default is the futurama config directory)")
+	quoteCmd.Flags().BoolVar(&NoHistory, "no-history", false, "Toggle for ignoring and not recording recently shown quotes")
+	quoteCmd.Flags().BoolVar(&QuoteBubble, "bubble", false, "Toggle for drawing quotes in speech bubbles with ASCII portraits of the speakers")
+	quoteCmd.Flags().StringVar(&QuoteStyle, "style", styleText, "How quote lines are laid out (text, screenplay)")
+	quoteCmd.Flags().BoolVar(&DailyQuote, "daily", false, "Toggle for returning the quote of the day")
+	quoteCmd.Flags().StringVar(&DailyDate, "date", "", "Date of the daily quote (YYYY-MM-DD, default today)")
+	quoteCmd.Flags().StringVar(&DailyTimezone, "tz", "", "Time zone used to determine the daily quote's date (e.g. 'America/New_York', default local)")
+	addSpeakerFlags(quoteCmd.Flags())
+	addLengthFlags(quoteCmd.Flags())
+	addTemplateFlags(quoteCmd.Flags())
 	// limit flag combos
-	quoteCmd.MarkFlagsMutuallyExclusive("season", "episode")
-	quoteCmd.MarkFlagsMutuallyExclusive("season", "all")
-	quoteCmd.MarkFlagsMutuallyExclusive("season", "character")
-	quoteCmd.MarkFlagsMutuallyExclusive("character", "all")
-	quoteCmd.MarkFlagsMutuallyExclusive("character", "episode")
+	quoteCmd.MarkFlagsMutuallyExclusive("daily", "all")
+	quoteCmd.MarkFlagsMutuallyExclusive("id", "from-favorites")
+	for _, f := range []string{"season", "episode", "character", "all", "daily"} {
+		quoteCmd.MarkFlagsMutuallyExclusive("id", f)
+	}
+	for _, f := range []string{"all", "daily", "id"} {
+		quoteCmd.MarkFlagsMutuallyExclusive("count", f)
+	}
+	quoteCmd.MarkFlagsMutuallyExclusive("context", "all")
+	for _, f := range []string{"all", "context"} {
+		quoteCmd.MarkFlagsMutuallyExclusive("bubble", f)
+	}
+	quoteCmd.MarkFlagsMutuallyExclusive("bubble", "style")
 }
 
 func validateInput(flags *pflag.FlagSet) error {
+	err := validateQuoteScope()
+	if err != nil {
+		return err
+	}
+
+	// validate --all is set with --episode or --character
+	if AllQuotes && QuoteEpisode == "" && QuoteCharacter == "" {
+		return errors.New("The --all flag must be set with the --episode or --character flag.")
+	}
+
+	// validate weighting
+	err = validateWeighting(QuoteWeighting)
+	if err != nil {
+		return err
+	}
+
+	// validate --count and --sort
+	err = validateCount()
+	if err != nil {
+		return err
+	}
+
+	// validate --context
+	err = validateContext()
+	if err != nil {
+		return err
+	}
+
+	// validate quote ID
+	if QuoteID != "" {
+		if _, _, _, err = parseQuoteID(QuoteID); err != nil {
+			return err
+		}
+	}
+
+	// validate favorites file
+	if FavoritesFile != "" && !FromFavorites {
+		return errors.New("The --favorites-file flag must be set with the --from-favorites flag.")
+	}
+	if FromFavorites {
+		if _, err = loadFavorites(FavoritesFile); err != nil {
+			return err
+		}
+	}
+
+	// validate --format and --template-file
+	err = validateTemplate()
+	if err != nil {
+		return err
+	}
+
+	// validate --bubble
+	err = validateBubble()
+	if err != nil {
+		return err
+	}
+
+	// validate --style
+	err = validateStyle()
+	if err != nil {
+		return err
+	}
+
+	// validate --date and --tz are set with --daily
+	if !DailyQuote && (DailyDate != "" || DailyTimezone != "") {
+		return errors.New("The --date and --tz flags must be set with the --daily flag.")
+	}
+	if DailyQuote {
+		if _, err = getDailyDate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateQuoteScope validates the --season, --episode and --character flags, and the speaker and
+// length filters, shared by every command that selects quotes
+func validateQuoteScope() error {
 	var err error
 
 	// validate season number
@@ -91,38 +259,46 @@ func validateInput(flags *pflag.FlagSet) error {
 
 	// validate episode name
 	if QuoteEpisode != "" {
-		err, QuoteSeason = validateEpisodeName(QuoteEpisode)
+		var episodeSeason int
+		err, episodeSeason = validateEpisodeName(QuoteEpisode)
 		if err != nil {
 			return err
 		}
+		if QuoteSeason != 0 && QuoteSeason != episodeSeason {
+			return fmt.Errorf("%s is not in Season %d. It is in Season %d.", QuoteEpisode, QuoteSeason, episodeSeason)
+		}
+		QuoteSeason = episodeSeason
 	}
 
 	// validate character input
 	if QuoteCharacter != "" {
-		supportedCharacters := getSupportedCharacters()
-
-		invalidCharacter := true
-		for _, c := range supportedCharacters {
-			if strings.ToLower(QuoteCharacter) == strings.ToLower(c) {
-				invalidCharacter = false
-				break
-			}
-		}
-
-		if invalidCharacter {
-			return errors.New("Invalid character input. Please use the 'futurama get characters' command for assistance.")
+		QuoteCharacter, err = getSupportedCharacter(QuoteCharacter)
+		if err != nil {
+			return err
 		}
+	}
 
+	// validate speaker filters
+	err = validateSpeakerFilters()
+	if err != nil {
+		return err
 	}
 
-	// validate --all is set with --episode
-	if AllQuotes && QuoteEpisode == "" {
-		return errors.New("The --all flag must be set with the --episode flag.")
+	// validate length filters
+	err = validateLengthFilters()
+	if err != nil {
+		return err
 	}
 
 	return nil
 }
 
+// useSinglePage reports whether a random quote can be picked by retrieving a single WikiQuote page
+func useSinglePage() bool {
+	return QuoteWeighting == weightSeason && !hasQuoteFilters() && !FromFavorites && QuoteCount == 1 &&
+		QuoteContext == 0
+}
+
 func randomize() {
 	series := getSeries()
 
@@ -135,7 +311,6 @@ func randomize() {
 	}
 
 	// randomize episode if not specified
-	// if character is specified, this will be re-randomized later
 	if QuoteEpisode == "" {
 		rand.Seed(time.Now().UnixNano())
 		min := 0
@@ -145,322 +320,284 @@ func randomize() {
 	}
 }
 
-func getQuotes() Season {
-	var season Season
-	var resp *http.Response
-
+func getQuotes() (Season, error) {
 	if QuoteSeason == 5 {
-		urlEpisode := "_" + strings.Replace(strings.Replace(QuoteEpisode, "'", "%27", -1), " ", "_", -1)
-		resp = getHttpResponse("https://en.wikiquote.org/wiki/Futurama:" + urlEpisode)
-		season = getSeasonFiveQuotes(resp)
-	} else {
-		resp = getHttpResponse("https://en.wikiquote.org/wiki/Futurama/Season_" + strconv.Itoa(QuoteSeason))
-		season = getSeasonQuotes(resp)
+		return getFilmQuotes(QuoteEpisode)
 	}
 
-	defer resp.Body.Close()
+	return getSeasonQuotesByNumber(QuoteSeason)
+}
 
-	return season
+// getSeasonTitle returns the title of a season's WikiQuote page
+func getSeasonTitle(seasonNumber int) string {
+	return "Futurama/Season " + strconv.Itoa(seasonNumber)
 }
 
-func getHttpResponse(url string) *http.Response {
-	var resp *http.Response
-	var err error
+// getFilmTitle returns the title of a Season 5 film's WikiQuote page
+func getFilmTitle(episode string) string {
+	return "Futurama: " + episode
+}
 
-	for i := 0; i < 5; i++ { // retry in case of bad response (mainly 404)
-		resp, err = http.Get(url)
-		ctype := resp.Header.Get("Content-Type")
+func getSeasonURL(seasonNumber int) string {
+	return wikiquote.pageURL(getSeasonTitle(seasonNumber))
+}
 
-		if err == nil && resp.StatusCode == http.StatusOK && strings.HasPrefix(ctype, "text/html") {
-			break
-		}
-		time.Sleep(time.Duration(i) * time.Second)
-	}
+func getFilmURL(episode string) string {
+	return wikiquote.pageURL(getFilmTitle(episode))
+}
+
+// getSeasonQuotes parses a WikiQuote season page, where each episode is a section
+// ending at the next heading, until the External links section
+func getSeasonQuotes(r io.Reader, seasonNumber int) (Season, error) {
+	var season = Season{name: "Season " + strconv.Itoa(seasonNumber)}
+	seen := map[string]int{}
 
+	doc, err := html.Parse(r)
 	if err != nil {
-		//.Fatalf() prints the error and exits the process
-		log.Fatalf("Error fetching WikiQuote URL: %v\n", err)
+		return season, errors.New("Error parsing WikiQuote page: " + err.Error())
 	}
 
-	//check response status code
-	if resp.StatusCode != http.StatusOK {
-		log.Fatalf("WikiQuote response status code was %d\n", resp.StatusCode)
-	}
+	blocks := getQuoteBlocks(doc)
+	for i := 0; i < len(blocks); i++ {
+		if getHeadingLevel(blocks[i]) == 0 { // before the first episode
+			continue
+		}
+		if getHeadingID(blocks[i]) == "External_links" { // reached end of quote page
+			break
+		}
 
-	//check response content type
-	ctype := resp.Header.Get("Content-Type")
-	if !strings.HasPrefix(ctype, "text/html") {
-		log.Fatalf("WikiQuote response content type was %s, not text/html\n", ctype)
+		ep := Episode{name: getVisibleText(blocks[i])}
+		quotes, n := getBlockQuotes(blocks[i+1:])
+		ep.quotes = quotes
+		i += n
+		setQuoteSource(&ep, seasonNumber, seen)
+		season.episodes = append(season.episodes, ep)
 	}
 
-	return resp
+	return season, nil
 }
 
-func getSeasonQuotes(resp *http.Response) Season {
-	var season = Season{name: "Season " + strconv.Itoa(QuoteSeason)}
+// getSeasonFiveQuotes parses the Dialogue section of a Season 5 film's WikiQuote page
+func getSeasonFiveQuotes(r io.Reader, seasonNumber int, episode string) (Season, error) {
+	var season = Season{name: "Season " + strconv.Itoa(seasonNumber)}
+	var ep = Episode{name: episode}
 
-	// tokenize WikiQuote response
-	tokenizer := html.NewTokenizer(resp.Body)
+	doc, err := html.Parse(r)
+	if err != nil {
+		return season, errors.New("Error parsing WikiQuote page: " + err.Error())
+	}
 
-seasonLoop:
-	for {
-		switch tokenizer.Next() {
-		case html.ErrorToken:
-			err := tokenizer.Err()
-			if err == io.EOF {
-				break seasonLoop //end of the file, break out of the loop
-			}
-			log.Fatalf("error tokenizing HTML: %v", tokenizer.Err())
-		case html.EndTagToken:
-			if "ul" == tokenizer.Token().Data { // end of episode list; start episodes/quotes section
-				for {
-					switch tokenizer.Next() {
-					case html.ErrorToken:
-						err := tokenizer.Err()
-						if err == io.EOF {
-							break seasonLoop //end of the file, break out of the loop
-						}
-						log.Fatalf("error tokenizing HTML: %v", tokenizer.Err())
-					case html.StartTagToken:
-						token := tokenizer.Token()
-						if "span" == token.Data { // found episode title line
-							for _, attr := range token.Attr {
-								if attr.Val == "External_links" { // reached end of quote page
-									break seasonLoop
-								}
-							}
-							episode := getEpisodeName(tokenizer)
-							season.episodes = append(season.episodes, episode)
-						}
-					}
-				}
-			}
+	blocks := getQuoteBlocks(doc)
+	for i, block := range blocks {
+		if getHeadingLevel(block) != 0 && getHeadingID(block) == "Dialogue" { // start parsing quotes
+			ep.quotes, _ = getBlockQuotes(blocks[i+1:])
+			break
 		}
 	}
 
-	return season
+	setQuoteSource(&ep, seasonNumber, map[string]int{})
+	season.episodes = append(season.episodes, ep)
+	return season, nil
 }
 
-func getEpisodeName(tokenizer *html.Tokenizer) Episode {
-	// initialize episode var
-	ep := Episode{}
-
-findEpisodeName:
-	for {
-		switch tokenizer.Next() {
-		case html.ErrorToken:
-			err := tokenizer.Err()
-			if err == io.EOF {
-				break findEpisodeName //end of the file, break out of the loop
-			}
-			log.Fatalf("error tokenizing HTML: %v", tokenizer.Err())
-		case html.TextToken:
-			ep.name = tokenizer.Token().Data
-			break findEpisodeName
+// getQuoteBlocks lists the headings, groups of quote lines (<dl>) and quote separators (<hr>) of a WikiQuote page,
+// in page order. Headings are either <h2> (with a span.mw-headline) or div.mw-heading, possibly within <section>s.
+func getQuoteBlocks(n *html.Node) []*html.Node {
+	blocks := []*html.Node{}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type != html.ElementNode {
+			continue
 		}
+		if getHeadingLevel(c) != 0 || c.DataAtom == atom.Dl || c.DataAtom == atom.Hr {
+			blocks = append(blocks, c)
+			continue
+		}
+		blocks = append(blocks, getQuoteBlocks(c)...)
 	}
-	// fmt.Println("\n" + ep.name)
-	ep.quotes = getEpisodeQuotes(tokenizer)
-	// fmt.Println(ep)
-	return ep
+
+	return blocks
 }
 
-func getEpisodeQuotes(tokenizer *html.Tokenizer) []Quote {
-	episodeQuotes := []Quote{}
+// getHeadingID returns the id of a heading: on the <h2> itself, or on its span.mw-headline
+func getHeadingID(heading *html.Node) string {
+	n := findNode(heading, func(n *html.Node) bool { return getAttr(n, "id") != "" })
+	if n == nil {
+		return ""
+	}
 
-findNextQuote:
-	for {
-		quote := Quote{}
-	getQuoteLines:
-		for {
-			switch tokenizer.Next() {
-			case html.ErrorToken:
-				err := tokenizer.Err()
-				if err == io.EOF {
-					break findNextQuote //end of the file, break out of the loop
-				}
-				log.Fatalf("error tokenizing HTML: %v", tokenizer.Err())
-			case html.StartTagToken:
-				switch tokenizer.Token().Data {
-				case "dl", "dd": // start of quote line
-					line := ""
-					speaker := false
-				getQuoteLine:
-					for {
-						switch tokenizer.Next() {
-						case html.ErrorToken:
-							err := tokenizer.Err()
-							if err == io.EOF {
-								break findNextQuote //end of the file, break out of the loop
-							}
-							log.Fatalf("error tokenizing HTML: %v", tokenizer.Err())
-						case html.StartTagToken:
-							if "b" == tokenizer.Token().Data { // bolded speaker of quote line
-								speaker = true
-							}
-						case html.TextToken:
-							token := tokenizer.Token()
-							line = line + token.Data
-							if speaker {
-								character := normalizeName(token.Data)
-								quote.characters = append(quote.characters, character)
-								speaker = false
-							}
-						case html.EndTagToken:
-							if "dd" == tokenizer.Token().Data { // end of quote line
-								quote.lines = append(quote.lines, line)
-								break getQuoteLine
-							}
-						}
-					}
-				case "h2", "h3": // start of new episode or end of quote section
-					episodeQuotes = append(episodeQuotes, quote)
-					break findNextQuote
-				}
+	return getAttr(n, "id")
+}
 
-			case html.SelfClosingTagToken:
-				if "hr" == tokenizer.Token().Data { // line break between quotes
-					episodeQuotes = append(episodeQuotes, quote)
-					break getQuoteLines
-				}
-			}
+// getBlockQuotes parses quotes from blocks until the next heading, returning them and the number of blocks read.
+// Quotes are the exchanges separated by <hr>.
+func getBlockQuotes(blocks []*html.Node) ([]Quote, int) {
+	episodeQuotes := []Quote{}
+	quote := Quote{}
+
+	read := len(blocks)
+	for i, block := range blocks {
+		if getHeadingLevel(block) != 0 { // start of new episode or end of quote section
+			read = i
+			break
+		}
+		if block.DataAtom == atom.Hr { // line break between quotes
+			episodeQuotes = append(episodeQuotes, quote)
+			quote = Quote{}
+			continue
+		}
+		for _, dd := range findNodes(block, atom.Dd) {
+			addQuoteLine(&quote, dd)
 		}
 	}
+	if len(quote.lines) > 0 || read < len(blocks) {
+		episodeQuotes = append(episodeQuotes, quote)
+	}
 
-	for _, x := range episodeQuotes {
-		unique.Sort(unique.StringSlice{&x.characters})
-		unique.Strings(&x.characters)
-		// fmt.Println(x.characters)
-		// fmt.Println(x.lines)
+	for i := range episodeQuotes {
+		unique.Sort(unique.StringSlice{P: &episodeQuotes[i].characters})
+		unique.Strings(&episodeQuotes[i].characters)
 	}
-	return episodeQuotes
+	return episodeQuotes, read
 }
 
-func getSeasonFiveQuotes(resp *http.Response) Season {
-	var season = Season{name: "Season " + strconv.Itoa(QuoteSeason)}
-	var ep = Episode{name: QuoteEpisode}
-
-	// tokenize WikiQuote response
-	tokenizer := html.NewTokenizer(resp.Body)
-
-episodeLoop:
-	for {
-		switch tokenizer.Next() {
-		case html.ErrorToken:
-			err := tokenizer.Err()
-			if err == io.EOF {
-				break episodeLoop //end of the file, break out of the loop
-			}
-			log.Fatalf("error tokenizing HTML: %v", tokenizer.Err())
-		case html.StartTagToken:
-			token := tokenizer.Token()
-			if "span" == token.Data {
-				for _, attr := range token.Attr {
-					if attr.Val == "Dialogue" { // start parsing quotes
-						ep.quotes = getEpisodeQuotes(tokenizer)
-						break episodeLoop
-					}
+// addQuoteLine adds the line of a <dd> to a quote. Bolded names are its speakers, the first one leads the line.
+func addQuoteLine(quote *Quote, dd *html.Node) {
+	var line strings.Builder
+	lineSpeaker := ""
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			line.WriteString(n.Data)
+			return
+		}
+		if n.DataAtom == atom.B { // bolded speaker of quote line
+			if name := findNode(n, func(n *html.Node) bool { return n.Type == html.TextNode }); name != nil {
+				character := normalizeName(name.Data)
+				quote.characters = append(quote.characters, character)
+				if lineSpeaker == "" {
+					lineSpeaker = character
 				}
 			}
 		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
 	}
+	walk(dd)
 
-	season.episodes = append(season.episodes, ep)
-	return season
+	quote.lines = append(quote.lines, line.String())
+	quote.speakers = append(quote.speakers, lineSpeaker)
 }
 
-func printQuotes(season Season) {
-	var ep Episode
-
-	fmt.Print("Season: ")
-	fmt.Println(QuoteSeason)
+// record where each quote came from so it can be printed outside of its episode.
+// seen counts the IDs given on the page, as an episode can be listed twice.
+func setQuoteSource(ep *Episode, seasonNumber int, seen map[string]int) {
+	for i := range ep.quotes {
+		ep.quotes[i].season = seasonNumber
+		ep.quotes[i].episode = ep.name
+		ep.quotes[i].id = getUniqueQuoteID(ep.quotes[i], seen)
+	}
+}
 
-	// find and print quote from character
-	if QuoteCharacter != "" {
-		// get subset of episodes with character present
-		subset := getCharacterEpisodes(season)
+func printQuotes(season Season) error {
+	quotes := excludeHistory(getEpisodePool(getEpisodeObject(season)), 1)
+	if len(quotes) == 0 {
+		return noQuotesError()
+	}
 
-		// re-randomize episode
-		epIndex := randomIndex(len(subset.episodes) - 1)
-		QuoteEpisode = subset.episodes[epIndex].name
+	q := quotes[randomIndex(len(quotes)-1)]
+	recordHistory(q)
 
-		fmt.Print("Episode: ")
-		fmt.Println(QuoteEpisode)
-		fmt.Println()
+	return printQuoteList([]Quote{q})
+}
 
-		// get random quote
-		qIndex := randomIndex(len(subset.episodes[epIndex].quotes) - 1)
-		for _, line := range subset.episodes[epIndex].quotes[qIndex].lines {
-			fmt.Println(line)
+func getEpisodeObject(season Season) Episode {
+	for _, ep := range season.episodes {
+		if matchesEpisode(ep.name, QuoteEpisode) {
+			return ep
 		}
+	}
 
-	} else if AllQuotes { // print all quotes from an episode
-		fmt.Print("Episode: ")
-		fmt.Println(QuoteEpisode)
-		fmt.Println()
+	return Episode{name: QuoteEpisode}
+}
 
-		ep = getEpisodeObject(season)
-		for _, q := range ep.quotes {
-			for _, line := range q.lines {
-				fmt.Println(line)
-			}
-			fmt.Println("----")
-		}
+// matchesEpisode compares a WikiQuote episode name with a name from getSeries
+func matchesEpisode(wikiQuoteName string, episode string) bool {
+	// catch episode names that are incorrect on WikiQuote
+	misnamedEpisode := ""
+	if episode == "The Lesser of Two Evils" {
+		misnamedEpisode = "Lesser of Two Evils"
+	}
 
-	} else { // season/episode have either been set or randomized
-		fmt.Print("Episode: ")
-		fmt.Println(QuoteEpisode)
-		fmt.Println()
+	return wikiQuoteName == episode || wikiQuoteName == misnamedEpisode
+}
 
-		ep = getEpisodeObject(season)
-		qIndex := randomIndex(len(ep.quotes) - 1)
-		for _, line := range ep.quotes[qIndex].lines {
-			fmt.Println(line)
-		}
+func printQuote(q Quote) {
+	printField("Season", q.season)
+	printField("Episode", q.episode)
+	if q.id != "" {
+		printField("ID", q.id)
 	}
+	fmt.Println()
 
+	if QuoteBubble {
+		printBubbleQuote(q)
+		return
+	}
+	printQuoteLines(q, "")
 }
 
-func getCharacterEpisodes(season Season) Season {
-	subset := Season{
-		name: season.name,
+// printQuoteLines prints the lines of a quote, each preceded by prefix, with colored speakers and stage directions
+// Lines are wrapped to the output width, with continuation lines indented past the speaker's name.
+func printQuoteLines(q Quote, prefix string) {
+	if QuoteStyle == styleScreenplay {
+		for _, line := range formatScreenplayLines(q) {
+			fmt.Println(strings.TrimRight(prefix+line, " "))
+		}
+		return
 	}
 
-	// loop through episodes to find onew with QuoteCharacter
-	for _, ep := range season.episodes {
-		subsetEp := Episode{name: ep.name}
-		for _, q := range ep.quotes {
-			for _, n := range q.characters {
-				if n == QuoteCharacter {
-					subsetEp.quotes = append(subsetEp.quotes, q)
-				}
-			}
-		}
-		if len(subsetEp.quotes) > 0 {
-			subset.episodes = append(subset.episodes, subsetEp)
+	for i, line := range q.lines {
+		speaker := ""
+		indent := 0
+		if i < len(q.speakers) && q.speakers[i] != "" {
+			speaker = q.speakers[i]
+			indent = visibleLength(speakerPrefix.FindString(line))
 		}
+		wrapped := wrapIndent(colorLine(line, speaker), wrapWidth-visibleLength(prefix), indent)
+		fmt.Println(prefix + strings.ReplaceAll(wrapped, "\n", "\n"+prefix))
 	}
-	// fmt.Println(subset)
-	return subset
 }
 
-func getEpisodeObject(season Season) Episode {
-	// catch episode names that are incorrect on WikiQuote
-	misnamedEpisode := ""
-	if QuoteEpisode == "The Lesser of Two Evils" {
-		misnamedEpisode = "Lesser of Two Evils"
+// printAllQuotes prints every quote in the filtered pool, grouped by episode
+func printAllQuotes() error {
+	pool, err := getFilteredPool()
+	if err != nil {
+		return err
+	}
+	if len(pool) == 0 {
+		return noQuotesError()
 	}
 
-	for _, ep := range season.episodes {
-		if ep.name == QuoteEpisode || ep.name == misnamedEpisode {
-			return ep
-		}
+	if isStructuredOutput() {
+		return writeQuotes(pool, false)
 	}
 
-	return Episode{
-		name:   "Error",
-		quotes: []Quote{{characters: []string{}, lines: []string{"error: no quotes found"}}},
+	for i, q := range pool {
+		if i == 0 || q.episode != pool[i-1].episode {
+			if i > 0 {
+				fmt.Println()
+			}
+			printField("Season", q.season)
+			printField("Episode", q.episode)
+			fmt.Println()
+		}
+		fmt.Println(colorize(theme.Header, "["+q.id+"]"))
+		printQuoteLines(q, "")
+		fmt.Println("----")
 	}
+
+	return nil
 }
 
 func randomIndex(max int) int {
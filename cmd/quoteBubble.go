@@ -0,0 +1,196 @@
+/*
+Copyright © 2023 Aric Hansen <aric.p.hansen@gmail.com>
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var QuoteBubble bool
+
+// width of the speech bubbles when the output width is unknown (e.g. when piping)
+const defaultBubbleWidth = 60
+
+// ASCII portraits drawn under speech bubbles, by normalized character name
+var portraits = map[string][]string{
+	"Fry": {
+		`      ,//////,`,
+		`     //////// \`,
+		`    |  o    o |`,
+		`    (    >     )`,
+		`     \  ---  /`,
+		`      '-----'`,
+	},
+	"Leela": {
+		`       .-""""-.___`,
+		`      /          __)`,
+		`     |   ( O )   |`,
+		`     |     ^     |`,
+		`      \   ---   /`,
+		`       '-.___.-'`,
+	},
+	"Bender": {
+		`         _`,
+		`        (_)`,
+		`         |`,
+		`      .-"""-.`,
+		`     /       \`,
+		`    | .-----. |`,
+		`    | | o o | |`,
+		`    | '-----' |`,
+		`    |  |||||  |`,
+		`    '---------'`,
+	},
+	"Prof. Farnsworth": {
+		`       .---.`,
+		`      /     \`,
+		`     (-@---@-)`,
+		`     |   >   |`,
+		`      \ ~~~ /`,
+		`       '---'`,
+	},
+	"Zoidberg": {
+		`       .---.`,
+		`      ( o o )`,
+		`      /     \`,
+		`      \ ||| /`,
+		`       |||||`,
+		`     (V)   (V)`,
+	},
+	"Hermes": {
+		`      ________`,
+		`     |________|`,
+		`     | O    O |`,
+		`     |   <>   |`,
+		`      \ ==== /`,
+		`       '----'`,
+	},
+	"Amy": {
+		`      .-~~~~-.`,
+		`     / ~~~~~~ \`,
+		`    |  ^    ^  |`,
+		`    |    v     |`,
+		`     \  \__/  /`,
+		`      '------'`,
+	},
+	"Zapp Brannigan": {
+		`       _______`,
+		`      /  ___  \`,
+		`     |  (o o)  |`,
+		`     |    >    |`,
+		`      \ \___/ /`,
+		`       '-----'`,
+	},
+}
+
+// portrait used for characters without one of their own
+var genericPortrait = []string{
+	`      .---.`,
+	`     ( o o )`,
+	`     |  -  |`,
+	`      '---'`,
+	`      /| |\`,
+}
+
+func validateBubble() error {
+	if QuoteBubble && isStructuredOutput() {
+		return errors.New("The --bubble flag cannot be used with --output, --format or --template-file.")
+	}
+
+	return nil
+}
+
+// printBubbleQuote draws a quote as speech bubbles, one per speaker turn, each followed by the speaker's portrait.
+// Stage directions between turns are printed on their own.
+func printBubbleQuote(q Quote) {
+	width := wrapWidth
+	if width <= 0 {
+		width = defaultBubbleWidth
+	}
+
+	turnSpeaker := ""
+	turn := []string{}
+	printTurn := func() {
+		if len(turn) > 0 {
+			printBubble(turn, width)
+			printPortrait(turnSpeaker)
+		}
+		turn = []string{}
+	}
+
+	for i, line := range q.lines {
+		speaker := ""
+		if i < len(q.speakers) {
+			speaker = q.speakers[i]
+		}
+
+		if speaker == "" {
+			printTurn()
+			fmt.Println(colorLine(wordWrap(width, strings.TrimSpace(line)), ""))
+			continue
+		}
+
+		if speaker != turnSpeaker {
+			printTurn()
+			turnSpeaker = speaker
+		}
+		turn = append(turn, strings.TrimSpace(speakerPrefix.ReplaceAllString(line, "")))
+	}
+	printTurn()
+}
+
+// printBubble draws text in a cowsay-style speech bubble that fits within width
+func printBubble(paragraphs []string, width int) {
+	textWidth := width - 4 // room for the borders
+	if textWidth < 10 {
+		textWidth = 10
+	}
+
+	lines := []string{}
+	for _, p := range paragraphs {
+		lines = append(lines, strings.Split(wordWrap(textWidth, p), "\n")...)
+	}
+
+	longest := 0
+	for _, line := range lines {
+		if l := visibleLength(line); l > longest {
+			longest = l
+		}
+	}
+
+	fmt.Println(" " + strings.Repeat("_", longest+2))
+	for i, line := range lines {
+		left, right := "|", "|"
+		switch {
+		case len(lines) == 1:
+			left, right = "<", ">"
+		case i == 0:
+			left, right = "/", "\\"
+		case i == len(lines)-1:
+			left, right = "\\", "/"
+		}
+		padding := strings.Repeat(" ", longest-visibleLength(line))
+		fmt.Println(left + " " + colorLine(line, "") + padding + " " + right)
+	}
+	fmt.Println(" " + strings.Repeat("-", longest+2))
+}
+
+// printPortrait draws the ASCII portrait of a speaker under a bubble, with their name
+func printPortrait(speaker string) {
+	portrait, ok := portraits[speaker]
+	if !ok {
+		portrait = genericPortrait
+	}
+
+	color := theme.Characters[speaker]
+	fmt.Println("    \\")
+	fmt.Println("     \\")
+	for _, line := range portrait {
+		fmt.Println(colorize(color, line))
+	}
+	fmt.Println(colorize(color, "    "+speaker))
+	fmt.Println()
+}
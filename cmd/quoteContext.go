@@ -0,0 +1,126 @@
+/*
+Copyright © 2023 Aric Hansen <aric.p.hansen@gmail.com>
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+)
+
+var QuoteContext int
+
+func validateContext() error {
+	if QuoteContext < 0 {
+		return errors.New("Invalid --context value. Please select a value of 0 or more.")
+	}
+
+	return nil
+}
+
+// getEpisodeQuoteList retrieves every quote from the episode a quote belongs to, in page order
+func getEpisodeQuoteList(q Quote) ([]Quote, error) {
+	var season Season
+	var err error
+	if n := getEpisodeNumber(q.season, q.episode); q.season == 5 && n != 0 {
+		season, err = getFilmQuotes(getSeries()[4].episodes[n-1])
+	} else {
+		season, err = getSeasonQuotesByNumber(q.season)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ep := range season.episodes {
+		if ep.name == q.episode {
+			return getEpisodePool(ep), nil
+		}
+	}
+
+	return []Quote{q}, nil
+}
+
+// getQuoteContext retrieves the quotes of the episode a quote belongs to, with the range to show
+// for --context. selected is -1 if the quote is no longer on WikiQuote.
+func getQuoteContext(q Quote) (quotes []Quote, start int, selected int, end int, err error) {
+	quotes, err = getEpisodeQuoteList(q)
+	if err != nil {
+		return nil, 0, -1, 0, err
+	}
+	selected = -1
+	for i, c := range quotes {
+		if c.id == q.id {
+			selected = i
+			break
+		}
+	}
+	if selected == -1 {
+		return quotes, 0, -1, 0, nil
+	}
+
+	start = selected - QuoteContext
+	end = selected + QuoteContext + 1
+	if start < 0 {
+		start = 0
+	}
+	if end > len(quotes) {
+		end = len(quotes)
+	}
+
+	return quotes, start, selected, end, nil
+}
+
+// printSelectedQuote prints a quote, surrounded by its --context if requested
+func printSelectedQuote(q Quote) error {
+	if QuoteContext == 0 {
+		printQuote(q)
+		return nil
+	}
+
+	quotes, start, selected, end, err := getQuoteContext(q)
+	if err != nil {
+		return err
+	}
+	if selected == -1 { // quote was edited or removed on WikiQuote
+		printQuote(q)
+		return nil
+	}
+
+	printField("Season", q.season)
+	printField("Episode", q.episode)
+	printField("ID", q.id)
+	fmt.Println()
+
+	for i := start; i < end; i++ {
+		if i > start {
+			fmt.Println("----")
+		}
+		fmt.Println(colorize(theme.Header, "["+quotes[i].id+"]"))
+		if i == selected { // highlight the selected quote
+			printQuoteLines(quotes[i], colorize(theme.Highlight, "> "))
+		} else {
+			printQuoteLines(quotes[i], "")
+		}
+	}
+
+	return nil
+}
+
+// printQuoteList prints quotes picked by 'get quote' in the --output format
+func printQuoteList(quotes []Quote) error {
+	if isStructuredOutput() {
+		return writeQuotes(quotes, true)
+	}
+
+	for i, q := range quotes {
+		if i > 0 {
+			fmt.Println("----")
+		}
+		err := printSelectedQuote(q)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
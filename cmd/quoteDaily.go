@@ -0,0 +1,69 @@
+/*
+Copyright © 2023 Aric Hansen <aric.p.hansen@gmail.com>
+*/
+package cmd
+
+import (
+	"errors"
+	"math/rand"
+	"time"
+)
+
+const dailyDateLayout = "2006-01-02"
+
+// getDailyDate returns the calendar day to pick a quote for, using --date and --tz if provided
+func getDailyDate() (time.Time, error) {
+	loc := time.Local
+	if DailyTimezone != "" {
+		var err error
+		loc, err = time.LoadLocation(DailyTimezone)
+		if err != nil {
+			return time.Time{}, errors.New("Invalid --tz value. Please use an IANA time zone name (e.g. 'America/New_York').")
+		}
+	}
+
+	if DailyDate == "" {
+		return time.Now().In(loc), nil
+	}
+
+	date, err := time.ParseInLocation(dailyDateLayout, DailyDate, loc)
+	if err != nil {
+		return time.Time{}, errors.New("Invalid --date value. Please use the YYYY-MM-DD format.")
+	}
+
+	return date, nil
+}
+
+// dailyIndex picks a position in a pool of the given size for a day.
+// Days are grouped into cycles as long as the pool, and every cycle walks a
+// different permutation of the pool, so no quote repeats within a cycle.
+func dailyIndex(date time.Time, size int, version uint64) int {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC).Unix() / 86400
+
+	cycle := day / int64(size)
+	position := day % int64(size)
+	if position < 0 { // dates before 1970
+		cycle--
+		position += int64(size)
+	}
+
+	r := rand.New(rand.NewSource(int64(version) ^ cycle))
+	return r.Perm(size)[position]
+}
+
+func printDailyQuote() error {
+	date, err := getDailyDate()
+	if err != nil {
+		return err
+	}
+
+	pool, err := getFilteredPool()
+	if err != nil {
+		return err
+	}
+	if len(pool) == 0 {
+		return noQuotesError()
+	}
+
+	return printQuoteList([]Quote{pool[dailyIndex(date, len(pool), corpusVersion(pool))]})
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDailyIndexCycle(t *testing.T) {
+	const size = 37
+	// start on the first day of a cycle, so the whole cycle shares one permutation
+	start := time.Unix(20000/size*size*86400, 0).UTC()
+
+	counts := make([]int, size)
+	for d := 0; d < size; d++ {
+		i := dailyIndex(start.AddDate(0, 0, d), size, 42)
+		if i < 0 || i >= size {
+			t.Fatalf("day %d: index %d is out of range", d, i)
+		}
+		counts[i]++
+	}
+	for i, n := range counts {
+		if n != 1 {
+			t.Errorf("index %d appeared %d times in a cycle, want once", i, n)
+		}
+	}
+}
+
+func TestDailyIndexVersion(t *testing.T) {
+	const size = 37
+	start := time.Unix(20000/size*size*86400, 0).UTC()
+
+	same := true
+	for d := 0; d < size && same; d++ {
+		date := start.AddDate(0, 0, d)
+		same = dailyIndex(date, size, 42) == dailyIndex(date, size, 43)
+	}
+	if same {
+		t.Error("changing the corpus version did not reshuffle the cycle")
+	}
+}
@@ -0,0 +1,179 @@
+/*
+Copyright © 2023 Aric Hansen <aric.p.hansen@gmail.com>
+*/
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/spf13/pflag"
+)
+
+// vars for storing speaker filter input
+var WithCharacters []string
+var OnlyCharacters []string
+var WithoutCharacters []string
+var MinSpeakers int
+
+// vars for storing length filter input
+var MaxLines int
+var MaxChars int
+var MinChars int
+var SingleLine bool
+
+// addSpeakerFlags registers the speaker filters on any command that selects quotes
+func addSpeakerFlags(flags *pflag.FlagSet) {
+	flags.StringArrayVar(&WithCharacters, "with", []string{}, "Only quotes where this character speaks (repeatable)")
+	flags.StringArrayVar(&OnlyCharacters, "only", []string{}, "Only quotes where these characters are the only speakers (repeatable)")
+	flags.StringArrayVar(&WithoutCharacters, "without", []string{}, "Only quotes where this character does not speak (repeatable)")
+	flags.IntVar(&MinSpeakers, "min-speakers", 0, "Only quotes with at least this many speakers")
+}
+
+// addLengthFlags registers the length filters on any command that selects quotes
+func addLengthFlags(flags *pflag.FlagSet) {
+	flags.IntVar(&MaxLines, "max-lines", 0, "Only quotes with at most this many lines")
+	flags.IntVar(&MaxChars, "max-chars", 0, "Only quotes with at most this many characters")
+	flags.IntVar(&MinChars, "min-chars", 0, "Only quotes with at least this many characters")
+	flags.BoolVar(&SingleLine, "single-line", false, "Only quotes with a single line")
+}
+
+func validateSpeakerFilters() error {
+	var err error
+
+	for _, names := range []*[]string{&WithCharacters, &OnlyCharacters, &WithoutCharacters} {
+		for i, name := range *names {
+			(*names)[i], err = getSupportedCharacter(name)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if MinSpeakers < 0 {
+		return errors.New("Invalid --min-speakers value. Please select a value of 0 or more.")
+	}
+
+	for _, w := range WithoutCharacters {
+		for _, c := range append(WithCharacters, OnlyCharacters...) {
+			if w == c {
+				return errors.New("The character " + w + " cannot be used with both --without and --with/--only.")
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateLengthFilters() error {
+	if MaxLines < 0 || MaxChars < 0 || MinChars < 0 {
+		return errors.New("Invalid length filter. Please use values of 0 or more.")
+	}
+
+	if MaxChars > 0 && MinChars > MaxChars {
+		return errors.New("The --min-chars value cannot be greater than the --max-chars value.")
+	}
+
+	return nil
+}
+
+// getSupportedCharacter returns the normalized name of a supported character, ignoring case
+func getSupportedCharacter(name string) (string, error) {
+	for _, c := range getSupportedCharacters() {
+		if strings.EqualFold(name, c) {
+			return c, nil
+		}
+	}
+
+	return "", errors.New("Invalid character input. Please use the 'futurama get characters' command for assistance.")
+}
+
+// hasQuoteFilters reports whether any filter narrows the quotes in scope
+func hasQuoteFilters() bool {
+	return QuoteCharacter != "" || hasSpeakerFilters() || hasLengthFilters()
+}
+
+func hasSpeakerFilters() bool {
+	return len(WithCharacters) > 0 || len(OnlyCharacters) > 0 || len(WithoutCharacters) > 0 || MinSpeakers > 0
+}
+
+func hasLengthFilters() bool {
+	return MaxLines > 0 || MaxChars > 0 || MinChars > 0 || SingleLine
+}
+
+// matchesQuoteFilters reports whether a quote passes the character, speaker and length filters
+func matchesQuoteFilters(q Quote) bool {
+	if QuoteCharacter != "" && !hasCharacter(q, QuoteCharacter) {
+		return false
+	}
+
+	return matchesSpeakerFilters(q) && matchesLengthFilters(q)
+}
+
+// matchesSpeakerFilters reports whether a quote passes the --with, --only, --without and --min-speakers filters
+func matchesSpeakerFilters(q Quote) bool {
+	for _, c := range WithCharacters {
+		if !hasCharacter(q, c) {
+			return false
+		}
+	}
+
+	for _, c := range WithoutCharacters {
+		if hasCharacter(q, c) {
+			return false
+		}
+	}
+
+	if len(OnlyCharacters) > 0 {
+		if len(q.characters) == 0 {
+			return false
+		}
+		for _, c := range q.characters {
+			only := false
+			for _, o := range OnlyCharacters {
+				if strings.EqualFold(c, o) {
+					only = true
+					break
+				}
+			}
+			if !only {
+				return false
+			}
+		}
+		for _, o := range OnlyCharacters {
+			if !hasCharacter(q, o) {
+				return false
+			}
+		}
+	}
+
+	return len(q.characters) >= MinSpeakers
+}
+
+// matchesLengthFilters reports whether a quote passes the --max-lines, --max-chars, --min-chars and --single-line filters
+func matchesLengthFilters(q Quote) bool {
+	if SingleLine && len(q.lines) != 1 {
+		return false
+	}
+	if MaxLines > 0 && len(q.lines) > MaxLines {
+		return false
+	}
+
+	chars := utf8.RuneCountInString(strings.Join(q.lines, "\n"))
+	if MaxChars > 0 && chars > MaxChars {
+		return false
+	}
+
+	return chars >= MinChars
+}
+
+func hasCharacter(q Quote, character string) bool {
+	for _, c := range q.characters {
+		if strings.EqualFold(c, character) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,126 @@
+/*
+Copyright © 2023 Aric Hansen <aric.p.hansen@gmail.com>
+*/
+package cmd
+
+import (
+	"log"
+	"time"
+)
+
+const historyVersion = 1
+
+// historySize is the number of recently shown quotes that are remembered
+const historySize = 256
+
+// QuoteHistory is the JSON document stored in the history file, oldest entry first
+type QuoteHistory struct {
+	Version int            `json:"version"`
+	Entries []HistoryEntry `json:"entries"`
+}
+
+type HistoryEntry struct {
+	ID    string    `json:"id"`
+	Shown time.Time `json:"shown"`
+}
+
+func loadHistory() (QuoteHistory, error) {
+	history := QuoteHistory{Version: historyVersion}
+
+	path, err := getDataPath("history.json")
+	if err != nil {
+		return history, err
+	}
+
+	err = readJSONFile(path, &history)
+	return history, err
+}
+
+func saveHistory(history QuoteHistory) error {
+	path, err := getDataPath("history.json")
+	if err != nil {
+		return err
+	}
+
+	if len(history.Entries) > historySize {
+		history.Entries = history.Entries[len(history.Entries)-historySize:]
+	}
+	history.Version = historyVersion
+
+	return writeJSONFile(path, history)
+}
+
+func (h QuoteHistory) contains(id string) bool {
+	for _, e := range h.Entries {
+		if e.ID == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// excludeHistory removes recently shown quotes from a pool, leaving at least count quotes to pick from.
+// If fewer than count quotes in the pool weren't shown recently, they are forgotten and the whole pool is returned.
+func excludeHistory(pool []Quote, count int) []Quote {
+	if NoHistory {
+		return pool
+	}
+
+	history, err := loadHistory()
+	if err != nil {
+		log.Printf("Unable to load quote history: %v\n", err)
+		return pool
+	}
+
+	unseen := []Quote{}
+	for _, q := range pool {
+		if !history.contains(q.id) {
+			unseen = append(unseen, q)
+		}
+	}
+	if len(unseen) > 0 && len(unseen) >= count {
+		return unseen
+	}
+
+	// not enough eligible quotes left to show, so start over
+	inPool := map[string]bool{}
+	for _, q := range pool {
+		inPool[q.id] = true
+	}
+	entries := []HistoryEntry{}
+	for _, e := range history.Entries {
+		if !inPool[e.ID] {
+			entries = append(entries, e)
+		}
+	}
+	history.Entries = entries
+
+	err = saveHistory(history)
+	if err != nil {
+		log.Printf("Unable to save quote history: %v\n", err)
+	}
+
+	return pool
+}
+
+// recordHistory remembers that quotes were shown, saving the history once
+func recordHistory(quotes ...Quote) {
+	if NoHistory {
+		return
+	}
+
+	history, err := loadHistory()
+	if err == nil {
+		shown := time.Now().UTC()
+		for _, q := range quotes {
+			if q.id != "" {
+				history.Entries = append(history.Entries, HistoryEntry{ID: q.id, Shown: shown})
+			}
+		}
+		err = saveHistory(history)
+	}
+	if err != nil {
+		log.Printf("Unable to save quote history: %v\n", err)
+	}
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestExcludeHistory(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	pool := []Quote{}
+	for i := 0; i < 4; i++ {
+		pool = append(pool, Quote{id: fmt.Sprintf("s01e01-0000000%d", i)})
+	}
+
+	recordHistory(pool[0], pool[1])
+	history, err := loadHistory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history.Entries) != 2 {
+		t.Fatalf("got %d history entries, want 2", len(history.Entries))
+	}
+
+	if got := excludeHistory(pool, 2); len(got) != 2 || got[0].id != pool[2].id || got[1].id != pool[3].id {
+		t.Errorf("got %d eligible quotes, want the 2 unseen ones", len(got))
+	}
+
+	// 3 quotes are requested but only 2 are unseen: the pool starts over
+	if got := excludeHistory(pool, 3); len(got) != len(pool) {
+		t.Errorf("got %d eligible quotes, want the whole pool", len(got))
+	}
+	history, err = loadHistory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history.Entries) != 0 {
+		t.Errorf("got %d history entries after starting over, want 0", len(history.Entries))
+	}
+}
@@ -0,0 +1,125 @@
+/*
+Copyright © 2023 Aric Hansen <aric.p.hansen@gmail.com>
+*/
+package cmd
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// quote IDs look like s03e05-1a2b3c4d: the season and episode numbers from getSeries,
+// followed by a hash of the quote's text so the ID survives reorderings of the WikiQuote page.
+// When the same text appears several times in an episode, the later occurrences get a suffix
+// with their count (s03e05-1a2b3c4d-2, s03e05-1a2b3c4d-3, ...).
+const quoteIDFormat = "s%02de%02d-%s"
+
+// occurrenceSuffix matches the optional suffix of repeated quotes (from -2)
+var occurrenceSuffix = regexp.MustCompile(`^(-([2-9]|[1-9][0-9]+))?$`)
+
+// getQuoteID builds the stable ID of a quote whose season and episode have been set, without the occurrence suffix
+func getQuoteID(q Quote) string {
+	return fmt.Sprintf(quoteIDFormat, q.season, getEpisodeNumber(q.season, q.episode), getQuoteHash(q))
+}
+
+// getUniqueQuoteID builds the ID of a quote, counting its occurrences in seen (shared by every quote of a page)
+// so repeated text gets unique IDs
+func getUniqueQuoteID(q Quote, seen map[string]int) string {
+	id := getQuoteID(q)
+	if len(q.lines) == 0 { // empty entries are never listed
+		return id
+	}
+
+	seen[id]++
+	if seen[id] > 1 {
+		id = fmt.Sprintf("%s-%d", id, seen[id])
+	}
+
+	return id
+}
+
+// getQuoteHash hashes the quote's lines, ignoring differences in whitespace
+func getQuoteHash(q Quote) string {
+	h := sha1.New()
+	for _, line := range q.lines {
+		h.Write([]byte(strings.Join(strings.Fields(line), " ")))
+		h.Write([]byte{'\n'})
+	}
+
+	return hex.EncodeToString(h.Sum(nil))[:8]
+}
+
+// getEpisodeNumber finds the 1-based index of a WikiQuote episode name in its season,
+// or 0 if the name is not in getSeries
+func getEpisodeNumber(seasonNumber int, wikiQuoteName string) int {
+	series := getSeries()
+	if seasonNumber < 1 || seasonNumber > len(series) {
+		return 0
+	}
+
+	for i, ep := range series[seasonNumber-1].episodes {
+		if matchesEpisode(wikiQuoteName, ep) {
+			return i + 1
+		}
+	}
+
+	return 0
+}
+
+// parseQuoteID splits a quote ID into its season number, episode number and hash (with its occurrence suffix, if any)
+func parseQuoteID(id string) (int, int, string, error) {
+	var seasonNumber, episodeNumber int
+	var hash string
+
+	invalidID := errors.New("Invalid quote ID. IDs look like 's03e05-1a2b3c4d'.")
+
+	_, err := fmt.Sscanf(strings.Replace(strings.ToLower(id), "-", " ", 1), "s%2de%2d %s", &seasonNumber, &episodeNumber, &hash)
+	if err != nil || len(hash) < 8 {
+		return 0, 0, "", invalidID
+	}
+	if _, err = hex.DecodeString(hash[:8]); err != nil {
+		return 0, 0, "", invalidID
+	}
+	if !occurrenceSuffix.MatchString(hash[8:]) {
+		return 0, 0, "", invalidID
+	}
+
+	series := getSeries()
+	if seasonNumber < 1 || seasonNumber > len(series) || episodeNumber > len(series[seasonNumber-1].episodes) {
+		return 0, 0, "", invalidID
+	}
+
+	return seasonNumber, episodeNumber, hash, nil
+}
+
+// getQuoteByID retrieves the quote with the given ID from WikiQuote
+func getQuoteByID(id string) (Quote, error) {
+	seasonNumber, episodeNumber, _, err := parseQuoteID(id)
+	if err != nil {
+		return Quote{}, err
+	}
+
+	var season Season
+	if episodeNumber == 0 { // episode name is not in getSeries, search the whole season
+		season, err = getSeasonQuotesByNumber(seasonNumber)
+	} else {
+		QuoteSeason = seasonNumber
+		QuoteEpisode = getSeries()[seasonNumber-1].episodes[episodeNumber-1]
+		season, err = getQuotes()
+	}
+	if err != nil {
+		return Quote{}, err
+	}
+
+	for _, q := range getQuotePool([]Season{season}) {
+		if q.id == strings.ToLower(id) {
+			return q, nil
+		}
+	}
+
+	return Quote{}, errors.New("Quote " + id + " was not found. It may have been edited or removed on WikiQuote.")
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGetUniqueQuoteID(t *testing.T) {
+	yes := Quote{season: 1, episode: "Space Pilot 3000", lines: []string{"Fry: Yes."}}
+	no := Quote{season: 1, episode: "Space Pilot 3000", lines: []string{"Fry: No."}}
+	empty := Quote{season: 1, episode: "Space Pilot 3000"}
+
+	seen := map[string]int{}
+	ids := []string{}
+	for _, q := range []Quote{yes, no, yes, empty, yes, empty} {
+		ids = append(ids, getUniqueQuoteID(q, seen))
+	}
+
+	base := getQuoteID(yes)
+	want := []string{base, getQuoteID(no), base + "-2", getQuoteID(empty), base + "-3", getQuoteID(empty)}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Errorf("quote %d: got ID %q, want %q", i, ids[i], want[i])
+		}
+	}
+}
+
+func TestRepeatedQuotesGetUniqueIDs(t *testing.T) {
+	page := `<h2><span class="mw-headline" id="Dialogue">Dialogue</span></h2>
+<dl><dd><b>Fry</b>: Yes.</dd></dl><hr>
+<dl><dd><b>Leela</b>: Are you sure?</dd></dl><hr>
+<dl><dd><b>Fry</b>: Yes.</dd></dl>`
+
+	season, err := getSeasonFiveQuotes(strings.NewReader(page), 5, "Bender's Big Score")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := map[string]bool{}
+	for _, q := range getEpisodePool(season.episodes[0]) {
+		if ids[q.id] {
+			t.Errorf("duplicate quote ID %s", q.id)
+		}
+		ids[q.id] = true
+	}
+	if len(ids) != 3 {
+		t.Errorf("got %d quotes, want 3", len(ids))
+	}
+}
+
+func TestParseQuoteID(t *testing.T) {
+	tests := []struct {
+		id    string
+		valid bool
+	}{
+		{"s03e05-1a2b3c4d", true},
+		{"S03E05-1A2B3C4D", true},
+		{"s03e05-1a2b3c4d-2", true},
+		{"s03e05-1a2b3c4d-12", true},
+		{"s03e05-1a2b3c4d-1", false},
+		{"s03e05-1a2b3c4d-02", false},
+		{"s03e05-1a2b3c4d-x", false},
+		{"s03e05-1a2b3c4", false},
+		{"s03e05-1a2b3c4g", false},
+		{"s09e01-1a2b3c4d", false},
+	}
+
+	for _, tt := range tests {
+		_, _, _, err := parseQuoteID(tt.id)
+		if (err == nil) != tt.valid {
+			t.Errorf("parseQuoteID(%q): got error %v, want valid %v", tt.id, err, tt.valid)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+/*
+Copyright © 2023 Aric Hansen <aric.p.hansen@gmail.com>
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// supported values for the --weighting flag
+const (
+	weightQuote   = "quote"   // every quote in scope is equally likely
+	weightEpisode = "episode" // every episode in scope is equally likely, then every quote in it
+	weightSeason  = "season"  // every season is equally likely, then every episode, then every quote
+)
+
+// supported values for the --sort flag
+const (
+	sortShuffle = "shuffle" // keep quotes in the order they were picked
+	sortEpisode = "episode" // put quotes in season and episode order
+)
+
+func getWeightings() []string {
+	return []string{weightQuote, weightEpisode, weightSeason}
+}
+
+func validateWeighting(weighting string) error {
+	for _, w := range getWeightings() {
+		if weighting == w {
+			return nil
+		}
+	}
+
+	return errors.New("Invalid weighting. Please select one of: quote, episode, season.")
+}
+
+func validateCount() error {
+	if QuoteCount < 1 {
+		return errors.New("Invalid --count value. Please select a value of 1 or more.")
+	}
+
+	if QuoteSort != sortShuffle && QuoteSort != sortEpisode {
+		return errors.New("Invalid sort order. Please select one of: shuffle, episode.")
+	}
+
+	return nil
+}
+
+// getScopeQuotes retrieves every season in scope: the user-defined episode or season, or the entire series
+func getScopeQuotes() ([]Season, error) {
+	if QuoteEpisode != "" || QuoteSeason != 0 {
+		var season Season
+		var err error
+		if QuoteEpisode != "" {
+			season, err = getQuotes()
+		} else {
+			season, err = getSeasonQuotesByNumber(QuoteSeason)
+		}
+		return []Season{season}, err
+	}
+
+	return getSeriesQuotes()
+}
+
+// getFilteredPool retrieves the quotes in scope that match the user-defined episode and filters, in corpus order.
+// Filters run before any random selection, so a pick only ever draws from matching quotes.
+func getFilteredPool() ([]Quote, error) {
+	var scope []Quote
+	if FromFavorites {
+		var err error
+		scope, err = getFavoriteQuotes()
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		seasons, err := getScopeQuotes()
+		if err != nil {
+			return nil, err
+		}
+		scope = getQuotePool(seasons)
+	}
+
+	pool := []Quote{}
+	for _, q := range scope {
+		if QuoteSeason != 0 && q.season != QuoteSeason {
+			continue
+		}
+		if QuoteEpisode != "" && !matchesEpisode(q.episode, QuoteEpisode) {
+			continue
+		}
+		if !matchesQuoteFilters(q) {
+			continue
+		}
+		pool = append(pool, q)
+	}
+
+	return pool, nil
+}
+
+// getFavoriteQuotes retrieves the quotes in the favorites collection, in the order they were added
+func getFavoriteQuotes() ([]Quote, error) {
+	collection, err := loadFavorites(FavoritesFile)
+	if err != nil {
+		return nil, errors.New("Error loading favorites: " + err.Error())
+	}
+
+	quotes := []Quote{}
+	for _, f := range collection.Favorites {
+		quotes = append(quotes, f.quote())
+	}
+
+	return quotes, nil
+}
+
+// noQuotesError describes the filters that left nothing to pick from
+func noQuotesError() error {
+	msg := "No quotes found"
+	if FromFavorites {
+		msg = "No favorite quotes found"
+	}
+	if QuoteCharacter != "" {
+		msg += " for " + QuoteCharacter
+	}
+	if hasSpeakerFilters() || hasLengthFilters() {
+		msg += " matching the filters"
+	}
+	if QuoteEpisode != "" {
+		msg += " in " + QuoteEpisode
+	} else if QuoteSeason != 0 {
+		msg += " in Season " + strconv.Itoa(QuoteSeason)
+	}
+
+	return errors.New(msg + ".")
+}
+
+// groupQuotes splits a pool into groups of quote indexes sharing the same key, in order of first appearance
+func groupQuotes(pool []Quote, indexes []int, key func(Quote) string) [][]int {
+	groups := [][]int{}
+	position := map[string]int{}
+	for _, i := range indexes {
+		k := key(pool[i])
+		if _, ok := position[k]; !ok {
+			position[k] = len(groups)
+			groups = append(groups, []int{})
+		}
+		groups[position[k]] = append(groups[position[k]], i)
+	}
+
+	return groups
+}
+
+// pickQuoteIndex picks the index of a random quote from a non-empty pool using the given weighting
+func pickQuoteIndex(pool []Quote, weighting string) int {
+	bySeason := func(q Quote) string { return strconv.Itoa(q.season) }
+	byEpisode := func(q Quote) string { return strconv.Itoa(q.season) + "/" + q.episode }
+
+	indexes := make([]int, len(pool))
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	switch weighting {
+	case weightSeason:
+		seasons := groupQuotes(pool, indexes, bySeason)
+		indexes = seasons[randomIndex(len(seasons)-1)]
+		fallthrough
+	case weightEpisode:
+		episodes := groupQuotes(pool, indexes, byEpisode)
+		indexes = episodes[randomIndex(len(episodes)-1)]
+	}
+
+	return indexes[randomIndex(len(indexes)-1)]
+}
+
+// sampleQuotes picks up to count distinct random quotes from a pool using the given weighting,
+// then puts them in episode order if requested
+func sampleQuotes(pool []Quote, count int, weighting string) []Quote {
+	remaining := []int{} // positions in the pool of the quotes not picked yet
+	for i := range pool {
+		remaining = append(remaining, i)
+	}
+	picked := []int{}
+	for len(picked) < count && len(remaining) > 0 {
+		quotes := []Quote{}
+		for _, p := range remaining {
+			quotes = append(quotes, pool[p])
+		}
+		i := pickQuoteIndex(quotes, weighting)
+		picked = append(picked, remaining[i])
+		remaining = append(remaining[:i], remaining[i+1:]...)
+	}
+
+	if QuoteSort == sortEpisode {
+		// the pool position breaks ties, keeping the quotes of an episode in WikiQuote order
+		sort.Slice(picked, func(i, j int) bool {
+			a, b := pool[picked[i]], pool[picked[j]]
+			if a.season != b.season {
+				return a.season < b.season
+			}
+			if x, y := getEpisodeNumber(a.season, a.episode), getEpisodeNumber(b.season, b.episode); x != y {
+				return x < y
+			}
+			return picked[i] < picked[j]
+		})
+	}
+
+	sample := []Quote{}
+	for _, p := range picked {
+		sample = append(sample, pool[p])
+	}
+
+	return sample
+}
+
+// printRandomQuote prints --count random quotes from the filtered pool
+func printRandomQuote() error {
+	pool, err := getFilteredPool()
+	if err != nil {
+		return err
+	}
+	if len(pool) == 0 {
+		return noQuotesError()
+	}
+
+	sample := sampleQuotes(excludeHistory(pool, QuoteCount), QuoteCount, QuoteWeighting)
+	if len(sample) < QuoteCount && !isStructuredOutput() {
+		fmt.Printf("Only %d quotes found, showing all of them.\n\n", len(sample))
+	}
+
+	recordHistory(sample...)
+
+	return printQuoteList(sample)
+}
@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGetFilteredPoolFavoritesError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "favorites.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	file, from := FavoritesFile, FromFavorites
+	FavoritesFile, FromFavorites = path, true
+	t.Cleanup(func() { FavoritesFile, FromFavorites = file, from })
+
+	_, err := getFilteredPool()
+	if err == nil || !strings.HasPrefix(err.Error(), "Error loading favorites: ") {
+		t.Errorf("got error %v, want an error loading favorites", err)
+	}
+}
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// WikiQuote pages as returned by the parse API, with the old and new heading markups
+var testSeasonPageOld = `<div class="mw-parser-output"><p><i>Futurama</i> season 1.</p>
+<ul><li><a>Space Pilot 3000</a></li><li><a>The Series Has Landed</a></li></ul>
+<h2><span class="mw-headline" id="Space_Pilot_3000">Space Pilot 3000</span><span class="mw-editsection"><span class="mw-editsection-bracket">[</span><a>edit</a><span class="mw-editsection-bracket">]</span></span></h2>
+<dl><dd><b>Fry</b>: Space. It seems to go on and on forever.</dd>
+<dd><b><a>Leela</a></b>: [<i>smiles</i>] Welcome to the world of tomorrow!</dd></dl>
+<hr>
+<dl><dd><b>Bender</b>: Bite my shiny metal ass!</dd></dl>
+<dl><dd><b>Fry</b> and <b>Leela</b>: Yes.</dd></dl>
+<hr>
+<dl><dd><b>Fry</b>: Yes.</dd></dl>
+<hr>
+<dl><dd><b>Fry</b>: Yes.</dd></dl>
+<h2><span class="mw-headline" id="The_Series_Has_Landed">The Series Has Landed</span><span class="mw-editsection">[<a>edit</a>]</span></h2>
+<dl><dd><b>Professor Farnsworth</b>: Good news, everyone!</dd></dl>
+<h2><span class="mw-headline" id="External_links">External links</span></h2>
+<ul><li><dl><dd><b>Not</b>: a quote</dd></dl></li></ul>
+</div>`
+
+var testSeasonPageNew = `<div class="mw-content-ltr mw-parser-output" lang="en" dir="ltr"><p><i>Futurama</i> season 1.</p>
+<ul><li><a>Space Pilot 3000</a></li><li><a>The Series Has Landed</a></li></ul>
+<div class="mw-heading mw-heading2"><h2 id="Space_Pilot_3000">Space Pilot 3000</h2><span class="mw-editsection"><span class="mw-editsection-bracket">[</span><a>edit</a><span class="mw-editsection-bracket">]</span></span></div>
+<dl><dd><b>Fry</b>: Space. It seems to go on and on forever.</dd>
+<dd><b><a>Leela</a></b>: [<i>smiles</i>] Welcome to the world of tomorrow!</dd></dl>
+<hr>
+<dl><dd><b>Bender</b>: Bite my shiny metal ass!</dd></dl>
+<dl><dd><b>Fry</b> and <b>Leela</b>: Yes.</dd></dl>
+<hr>
+<dl><dd><b>Fry</b>: Yes.</dd></dl>
+<hr>
+<dl><dd><b>Fry</b>: Yes.</dd></dl>
+<section><div class="mw-heading mw-heading2"><h2 id="The_Series_Has_Landed">The Series Has Landed</h2><span class="mw-editsection">[<a>edit</a>]</span></div>
+<dl><dd><b>Professor Farnsworth</b>: Good news, everyone!</dd></dl></section>
+<div class="mw-heading mw-heading2"><h2 id="External_links">External links</h2></div>
+<ul><li><dl><dd><b>Not</b>: a quote</dd></dl></li></ul>
+</div>`
+
+var testFilmSectionOld = `<div class="mw-parser-output"><h2><span class="mw-headline" id="Dialogue">Dialogue</span><span class="mw-editsection">[<a>edit</a>]</span></h2>
+<dl><dd><b>Fry</b>: Hi.</dd></dl>
+<hr>
+<dl><dd><b>Bender</b>: Bite.</dd></dl>
+</div>`
+
+var testFilmSectionNew = `<div class="mw-content-ltr mw-parser-output"><div class="mw-heading mw-heading2"><h2 id="Dialogue">Dialogue</h2><span class="mw-editsection">[<a>edit</a>]</span></div>
+<dl><dd><b>Fry</b>: Hi.</dd></dl>
+<hr>
+<dl><dd><b>Bender</b>: Bite.</dd></dl>
+</div>`
+
+var testFilmPageNew = `<div class="mw-content-ltr mw-parser-output"><p>Intro.</p>
+<div class="mw-heading mw-heading2"><h2 id="Cast">Cast</h2></div><dl><dd><b>Not</b>: a quote</dd></dl>
+<div class="mw-heading mw-heading2"><h2 id="Dialogue">Dialogue</h2></div>
+<dl><dd><b>Fry</b>: Hi.</dd></dl>
+<hr>
+<dl><dd><b>Bender</b>: Bite.</dd></dl>
+<div class="mw-heading mw-heading2"><h2 id="External_links">External links</h2></div><dl><dd><b>Not</b>: a quote</dd></dl>
+</div>`
+
+// testQuote is the part of a parsed quote checked by the tests
+type testQuote struct {
+	lines      []string
+	speakers   []string
+	characters []string
+}
+
+func getTestQuotes(ep Episode) []testQuote {
+	quotes := []testQuote{}
+	for _, q := range getEpisodePool(ep) {
+		quotes = append(quotes, testQuote{q.lines, q.speakers, q.characters})
+	}
+
+	return quotes
+}
+
+func TestGetSeasonQuotes(t *testing.T) {
+	want := map[string][]testQuote{
+		"Space Pilot 3000": {
+			{
+				[]string{"Fry: Space. It seems to go on and on forever.", "Leela: [smiles] Welcome to the world of tomorrow!"},
+				[]string{"Fry", "Leela"}, []string{"Fry", "Leela"},
+			},
+			{
+				[]string{"Bender: Bite my shiny metal ass!", "Fry and Leela: Yes."},
+				[]string{"Bender", "Fry"}, []string{"Bender", "Fry", "Leela"},
+			},
+			{[]string{"Fry: Yes."}, []string{"Fry"}, []string{"Fry"}},
+			{[]string{"Fry: Yes."}, []string{"Fry"}, []string{"Fry"}},
+		},
+		"The Series Has Landed": {
+			{[]string{"Professor Farnsworth: Good news, everyone!"}, []string{"Prof. Farnsworth"}, []string{"Prof. Farnsworth"}},
+		},
+	}
+
+	var ids [][]string
+	for _, page := range []string{testSeasonPageOld, testSeasonPageNew} {
+		season, err := getSeasonQuotes(strings.NewReader(page), 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(season.episodes) != len(want) {
+			t.Fatalf("got %d episodes, want %d", len(season.episodes), len(want))
+		}
+
+		pageIDs := []string{}
+		for _, ep := range season.episodes {
+			if got := getTestQuotes(ep); !reflect.DeepEqual(got, want[ep.name]) {
+				t.Errorf("episode %q: got %+v, want %+v", ep.name, got, want[ep.name])
+			}
+			for _, q := range getEpisodePool(ep) {
+				pageIDs = append(pageIDs, q.id)
+			}
+		}
+		ids = append(ids, pageIDs)
+	}
+
+	if !reflect.DeepEqual(ids[0], ids[1]) {
+		t.Errorf("IDs differ between heading markups: %v and %v", ids[0], ids[1])
+	}
+}
+
+func TestGetSeasonFiveQuotes(t *testing.T) {
+	want := []testQuote{
+		{[]string{"Fry: Hi."}, []string{"Fry"}, []string{"Fry"}},
+		{[]string{"Bender: Bite."}, []string{"Bender"}, []string{"Bender"}},
+	}
+
+	for name, page := range map[string]string{"old section": testFilmSectionOld, "new section": testFilmSectionNew, "new page": testFilmPageNew} {
+		season, err := getSeasonFiveQuotes(strings.NewReader(page), 5, "Bender's Big Score")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := getTestQuotes(season.episodes[0]); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got %+v, want %+v", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+/*
+Copyright © 2023 Aric Hansen <aric.p.hansen@gmail.com>
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"image/png"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var RenderID string
+var RenderFile string
+var RenderSize string
+var RenderTheme string
+var RenderBackground string
+
+var renderCmd = &cobra.Command{
+	Use:   "render",
+	Short: "Render a quote as a PNG image card",
+	Long: `Render a quote as a PNG image card to share, with the speakers' names, the episode title and season.
+
+Size presets:
+  - square: 1080x1080 (default)
+  - banner: 1500x500
+  - story: 1080x1920
+
+Themes:
+  - dark: light text on a dark background (default)
+  - light: dark text on a light background
+  - character: a background in the color of the first speaker (see the theme in the config file)
+
+Quotes saved as favorites are rendered from the saved copy, without contacting WikiQuote.`,
+	Example: `  futurama render --id s01e01-1a2b3c4d
+  futurama render --id s01e01-1a2b3c4d -o card.png --size banner
+  futurama render --id s01e01-1a2b3c4d --theme character
+  futurama render --id s01e01-1a2b3c4d --background "#0b3d2e"`,
+	Run: func(cmd *cobra.Command, args []string) {
+		err := renderQuote()
+		if err != nil {
+			fmt.Println(err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(renderCmd)
+	renderCmd.Flags().StringVar(&RenderID, "id", "", "ID of the quote to render (e.g. 's03e05-1a2b3c4d')")
+	renderCmd.Flags().StringVarP(&RenderFile, "output-file", "o", "", "Path of the PNG file (default is <id>.png)")
+	renderCmd.Flags().StringVar(&RenderSize, "size", cardSquare, "Size preset of the card (square, banner, story)")
+	renderCmd.Flags().StringVar(&RenderTheme, "theme", cardDark, "Color theme of the card (dark, light, character)")
+	renderCmd.Flags().StringVar(&RenderBackground, "background", "", "Background color of the card as a color name or hex value (e.g. '#0b3d2e'), instead of the theme's")
+	renderCmd.MarkFlagRequired("id")
+}
+
+func renderQuote() error {
+	size, ok := cardSizes[RenderSize]
+	if !ok {
+		return errors.New("Invalid size. Please select one of: square, banner, story.")
+	}
+	if RenderTheme != cardDark && RenderTheme != cardLight && RenderTheme != cardCharacter {
+		return errors.New("Invalid theme. Please select one of: dark, light, character.")
+	}
+	if RenderBackground != "" {
+		if _, ok := getCardColor(RenderBackground); !ok {
+			return errors.New("Invalid background color. Please use a color name (e.g. 'orange') or a hex value (e.g. '#ff8800').")
+		}
+	}
+	if _, _, _, err := parseQuoteID(RenderID); err != nil {
+		return err
+	}
+
+	q, err := getRenderedQuote(RenderID)
+	if err != nil {
+		return err
+	}
+
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	cardTheme := mergeTheme(getDefaultTheme(), config.Theme)
+
+	img, err := drawCard(q, size, cardTheme)
+	if err != nil {
+		return err
+	}
+
+	path := RenderFile
+	if path == "" {
+		path = q.id + ".png"
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	err = png.Encode(f, img)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Rendered " + q.id + " to " + path + ".")
+	return nil
+}
+
+// getRenderedQuote looks up a quote in the favorites first, so saved quotes don't need WikiQuote
+func getRenderedQuote(id string) (Quote, error) {
+	collection, err := loadFavorites("")
+	if err != nil {
+		return Quote{}, err
+	}
+	if i := collection.find(id); i != -1 {
+		return collection.Favorites[i].quote(), nil
+	}
+
+	return getQuoteByID(id)
+}
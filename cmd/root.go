@@ -34,10 +34,34 @@ var rootCmd = &cobra.Command{
 plot synopses of episodes.
 	
 Quotes can be retrieved from:
-  - a random episode in a random season
-  - a random episode in a user-defined season
+  - the entire series
+  - a user-defined season
   - a user-defined episode
-  - a random episode in a random season from a user-defined character `,
+  - a user-defined character, searched across the entire series
+
+Quotes can also be exported to other formats, such as screenplays (see 'export').
+Use --db to read quotes from a database created with 'export sqlite' instead of WikiQuote.
+
+Use --output to print json, yaml or ndjson instead of text.
+
+Character names, stage directions and headers are colored when printing to a terminal.
+Use --color or the NO_COLOR environment variable to change this.
+
+Text is wrapped to the terminal width (or --width), and long output is shown
+through $PAGER when printing to a terminal (unless --no-pager is set). `,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		err := validateOutput()
+		if err == nil {
+			err = validateWidth()
+		}
+		if err == nil {
+			err = validateCorpusDB()
+		}
+		if err != nil {
+			return err
+		}
+		return validateColor()
+	},
 }
 
 func Execute() {
@@ -47,4 +71,10 @@ func Execute() {
 	}
 }
 
-func init() {}
+func init() {
+	rootCmd.PersistentFlags().StringVar(&ColorMode, "color", colorAuto, "When to color output (auto, always, never)")
+	rootCmd.PersistentFlags().StringVar(&OutputFormat, "output", outputText, "Output format (text, json, yaml, ndjson)")
+	rootCmd.PersistentFlags().IntVar(&OutputWidth, "width", 0, "Width that text is wrapped to (default is the terminal width)")
+	rootCmd.PersistentFlags().StringVar(&CorpusDB, "db", "", "Path of a SQLite database created with 'export sqlite', used instead of WikiQuote")
+	rootCmd.PersistentFlags().BoolVar(&NoPager, "no-pager", false, "Toggle for printing long output directly instead of through $PAGER")
+}
@@ -0,0 +1,173 @@
+/*
+Copyright © 2023 Aric Hansen <aric.p.hansen@gmail.com>
+*/
+package cmd
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var QuoteStyle string
+
+// supported values for the --style flag
+const (
+	styleText       = "text"       // quote lines as they appear on WikiQuote (default)
+	styleScreenplay = "screenplay" // character cues, parentheticals and dialogue laid out like a screenplay
+)
+
+// width of a screenplay page in characters (6 inches of 10-pitch Courier)
+const screenplayWidth = 60
+
+// kinds of screenplay elements
+const (
+	elementScene         = "scene"         // scene heading
+	elementAction        = "action"        // stage direction on its own line
+	elementTransition    = "transition"    // cut to the next exchange, aligned right
+	elementCharacter     = "character"     // character cue, before their dialogue
+	elementParenthetical = "parenthetical" // stage direction within a line of dialogue
+	elementDialogue      = "dialogue"
+)
+
+// directedSpeakerPrefix matches a speaker's name followed by stage directions, e.g. "Fry [whispering]:"
+var directedSpeakerPrefix = regexp.MustCompile(`^([^:\[\]]{1,60}?)\s*((?:\[[^\]]*\]\s*)+):\s*`)
+
+type scriptElement struct {
+	kind    string
+	text    string
+	speaker string // normalized name of the speaker, for colors
+}
+
+func validateStyle() error {
+	if QuoteStyle != styleText && QuoteStyle != styleScreenplay {
+		return errors.New("Invalid style. Please select one of: text, screenplay.")
+	}
+	if QuoteStyle == styleScreenplay && isStructuredOutput() {
+		return errors.New("The --style flag cannot be used with --output, --format or --template-file.")
+	}
+
+	return nil
+}
+
+// getScriptElements breaks the lines of a quote into screenplay elements.
+// Stage directions within a line become parentheticals, and lines without a speaker's name become action.
+func getScriptElements(q Quote) []scriptElement {
+	elements := []scriptElement{}
+	for i, line := range q.lines {
+		line = strings.TrimSpace(line)
+		speaker := ""
+		if i < len(q.speakers) {
+			speaker = q.speakers[i]
+		}
+
+		prefix := speakerPrefix.FindString(line)
+		cue := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(prefix), ":"))
+		text := line[len(prefix):]
+		if m := directedSpeakerPrefix.FindStringSubmatch(line); prefix == "" && m != nil {
+			// directions between the name and the colon (e.g. "Fry [whispering]:") open the dialogue
+			cue = strings.TrimSpace(m[1])
+			text = m[2] + line[len(m[0]):]
+		}
+		if speaker == "" || cue == "" { // no written name to use as a cue
+			elements = append(elements, scriptElement{kind: elementAction, text: trimDirection(line)})
+			continue
+		}
+
+		// consecutive lines of the same speaker share a single cue
+		if n := len(elements); n == 0 || elements[n-1].kind == elementAction || elements[n-1].speaker != speaker {
+			elements = append(elements, scriptElement{kind: elementCharacter, text: strings.ToUpper(cue), speaker: speaker})
+		}
+
+		last := 0
+		for _, loc := range stageDirection.FindAllStringIndex(text, -1) {
+			if dialogue := strings.TrimSpace(text[last:loc[0]]); dialogue != "" {
+				elements = append(elements, scriptElement{kind: elementDialogue, text: dialogue, speaker: speaker})
+			}
+			direction := trimDirection(text[loc[0]:loc[1]])
+			elements = append(elements, scriptElement{kind: elementParenthetical, text: "(" + direction + ")", speaker: speaker})
+			last = loc[1]
+		}
+		if dialogue := strings.TrimSpace(text[last:]); dialogue != "" {
+			elements = append(elements, scriptElement{kind: elementDialogue, text: dialogue, speaker: speaker})
+		}
+	}
+
+	return elements
+}
+
+// trimDirection removes the brackets around a stage direction
+func trimDirection(direction string) string {
+	return strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(direction, "["), "]"))
+}
+
+// renderScreenplay lays out screenplay elements on a page of width characters.
+// Each block (a scene heading, an action or a character's cue with their dialogue) is returned separately,
+// so blocks are never split across pages. Cues and directions are colored if color is set.
+func renderScreenplay(elements []scriptElement, width int, color bool) [][]string {
+	paint := func(name string, text string) string {
+		if !color {
+			return text
+		}
+		return colorize(name, text)
+	}
+	indented := func(text string, indent int, textWidth int, name string) []string {
+		lines := []string{}
+		for _, line := range strings.Split(wordWrap(textWidth, text), "\n") {
+			lines = append(lines, strings.Repeat(" ", indent)+paint(name, line))
+		}
+		return lines
+	}
+
+	blocks := [][]string{}
+	for _, e := range elements {
+		switch e.kind {
+		case elementScene:
+			blocks = append(blocks, []string{paint(theme.Header, strings.ToUpper(e.text))})
+		case elementAction:
+			blocks = append(blocks, indented(e.text, 0, width, theme.StageDirection))
+		case elementTransition:
+			indent := width - len(e.text)
+			if indent < 0 {
+				indent = 0
+			}
+			blocks = append(blocks, []string{strings.Repeat(" ", indent) + paint(theme.Header, e.text)})
+		case elementCharacter:
+			indent := (width - len(e.text)) / 2
+			if indent < 0 {
+				indent = 0
+			}
+			blocks = append(blocks, []string{strings.Repeat(" ", indent) + paint(theme.Characters[e.speaker], e.text)})
+		case elementParenthetical:
+			last := len(blocks) - 1
+			blocks[last] = append(blocks[last], indented(e.text, width/4, width*5/12, theme.StageDirection)...)
+		case elementDialogue:
+			last := len(blocks) - 1
+			blocks[last] = append(blocks[last], indented(e.text, width/6, width*7/12, "")...)
+		}
+	}
+
+	return blocks
+}
+
+// getScreenplayWidth returns the page width used for --style screenplay, narrowed to fit the output width
+func getScreenplayWidth() int {
+	if wrapWidth > 0 && wrapWidth < screenplayWidth {
+		return wrapWidth
+	}
+
+	return screenplayWidth
+}
+
+// formatScreenplayLines renders a quote with --style screenplay, blocks separated by blank lines
+func formatScreenplayLines(q Quote) []string {
+	lines := []string{}
+	for i, block := range renderScreenplay(getScriptElements(q), getScreenplayWidth(), true) {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, block...)
+	}
+
+	return lines
+}
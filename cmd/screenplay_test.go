@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetScriptElements(t *testing.T) {
+	q := Quote{
+		lines:    []string{"Fry: Yes. [nods]", "[Leela sighs.]", "Fry [whispering]: Yes.", "Leela: No.", "Leela [to Fry] [angrily]: No!"},
+		speakers: []string{"Fry", "", "Fry", "Leela", "Leela"},
+	}
+
+	want := []scriptElement{
+		{kind: elementCharacter, text: "FRY", speaker: "Fry"},
+		{kind: elementDialogue, text: "Yes.", speaker: "Fry"},
+		{kind: elementParenthetical, text: "(nods)", speaker: "Fry"},
+		{kind: elementAction, text: "Leela sighs."},
+		{kind: elementCharacter, text: "FRY", speaker: "Fry"},
+		{kind: elementParenthetical, text: "(whispering)", speaker: "Fry"},
+		{kind: elementDialogue, text: "Yes.", speaker: "Fry"},
+		{kind: elementCharacter, text: "LEELA", speaker: "Leela"},
+		{kind: elementDialogue, text: "No.", speaker: "Leela"},
+		{kind: elementParenthetical, text: "(to Fry)", speaker: "Leela"},
+		{kind: elementParenthetical, text: "(angrily)", speaker: "Leela"},
+		{kind: elementDialogue, text: "No!", speaker: "Leela"},
+	}
+	if got := getScriptElements(q); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
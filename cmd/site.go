@@ -0,0 +1,21 @@
+/*
+Copyright © 2023 Aric Hansen <aric.p.hansen@gmail.com>
+*/
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var siteCmd = &cobra.Command{
+	Use:   "site",
+	Short: "Generate a static website of quotes and plots",
+	Long: `Generate a static website to browse every quote and plot, with a page per season,
+episode and character, and a search box that works without a server.`,
+	Example: `  futurama site build -o ./public`,
+	Args:    cobra.ExactArgs(1),
+}
+
+func init() {
+	rootCmd.AddCommand(siteCmd)
+}
@@ -0,0 +1,48 @@
+// Client-side search of the quotes in search-index.js: every word of the query must appear
+// in the quote's text, speakers, episode or ID.
+(function () {
+  var input = document.getElementById("search");
+  var results = document.getElementById("results");
+  if (!input || !results || typeof searchIndex === "undefined") {
+    return;
+  }
+
+  var maxResults = 50;
+
+  function search(query) {
+    results.innerHTML = "";
+    var words = query.toLowerCase().split(/\s+/).filter(Boolean);
+    if (words.length === 0) {
+      return;
+    }
+
+    var count = 0;
+    for (var i = 0; i < searchIndex.length && count < maxResults; i++) {
+      var entry = searchIndex[i];
+      var haystack = (entry.t + " " + entry.s + " " + entry.e + " " + entry.id).toLowerCase();
+      if (words.every(function (word) { return haystack.indexOf(word) !== -1; })) {
+        var item = document.createElement("li");
+        var link = document.createElement("a");
+        link.href = entry.u;
+        link.textContent = entry.t;
+        item.appendChild(link);
+        item.appendChild(document.createTextNode(" — " + entry.s + ", " + entry.e));
+        results.appendChild(item);
+        count++;
+      }
+    }
+    if (count === 0) {
+      results.innerHTML = "<li>No quotes found.</li>";
+    }
+  }
+
+  input.addEventListener("input", function () {
+    search(input.value);
+  });
+
+  var query = new URLSearchParams(window.location.search).get("q");
+  if (query) {
+    input.value = query;
+    search(query);
+  }
+})();
@@ -0,0 +1,327 @@
+/*
+Copyright © 2023 Aric Hansen <aric.p.hansen@gmail.com>
+*/
+package cmd
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var SiteDir string
+var SiteNoPlots bool
+
+// templates and static files of the generated site
+//
+//go:embed site
+var siteFiles embed.FS
+
+var siteBuildCmd = &cobra.Command{
+	Use:   "build",
+	Short: "Build the static website",
+	Long: `Build a static website of every quote and plot into a directory, ready to be hosted by any web server.
+
+The site has a page per season, episode (with its plot from Wikipedia) and supported character,
+a permalink per quote matching its ID (quotes/<id>.html), and a search index used by the search box.
+
+Quotes come from WikiQuote and plots from Wikipedia, both under the CC BY-SA license.
+Every page links to its sources and includes the license notice.`,
+	Example: `  futurama site build
+  futurama site build -o ./public
+  futurama site build --no-plots`,
+	Run: func(cmd *cobra.Command, args []string) {
+		err := buildSite()
+		if err != nil {
+			fmt.Println(err)
+		}
+	},
+}
+
+func init() {
+	siteCmd.AddCommand(siteBuildCmd)
+	siteBuildCmd.Flags().StringVarP(&SiteDir, "output-dir", "o", "public", "Directory the site is written to")
+	siteBuildCmd.Flags().BoolVar(&SiteNoPlots, "no-plots", false, "Toggle for skipping plots, which are retrieved from Wikipedia one episode at a time")
+}
+
+// sitePage is the data passed to the layout template of every page
+type sitePage struct {
+	Title   string
+	Content any
+}
+
+type siteIndex struct {
+	Seasons    []*siteSeason
+	Characters []*siteCharacter
+}
+
+type siteSeason struct {
+	Number   int
+	Name     string
+	URL      string
+	Episodes []*siteEpisode
+}
+
+type siteEpisode struct {
+	Season     int
+	Number     int
+	Title      string
+	URL        string
+	Plot       []string
+	PlotSource string
+	Source     string
+	Quotes     []siteQuote
+}
+
+type siteCharacter struct {
+	Name   string
+	URL    string
+	Quotes []siteQuote
+}
+
+type siteQuote struct {
+	QuoteDocument
+	URL        string // permalink
+	EpisodeURL string // the quote on its episode page
+}
+
+// siteSearchEntry is an entry of the search index, kept short as the index holds every quote
+type siteSearchEntry struct {
+	ID       string `json:"id"`
+	Episode  string `json:"e"`
+	Speakers string `json:"s"`
+	Text     string `json:"t"`
+	URL      string `json:"u"`
+}
+
+// siteSlug matches the characters replaced by dashes in page names
+var siteSlug = regexp.MustCompile(`[^a-z0-9]+`)
+
+func getSlug(name string) string {
+	return strings.Trim(siteSlug.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+func buildSite() error {
+	seasons, characters, err := getSiteContent()
+	if err != nil {
+		return err
+	}
+
+	for _, dir := range []string{"", "seasons", "episodes", "characters", "quotes"} {
+		err := os.MkdirAll(filepath.Join(SiteDir, dir), 0755)
+		if err != nil {
+			return err
+		}
+	}
+
+	// static files
+	for _, name := range []string{"style.css", "search.js"} {
+		data, err := siteFiles.ReadFile("site/" + name)
+		if err != nil {
+			return err
+		}
+		err = os.WriteFile(filepath.Join(SiteDir, name), data, 0644)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = writeSitePage("index.html", "index.html", "", "Futurama quotes", siteIndex{seasons, characters})
+	if err != nil {
+		return err
+	}
+
+	index := []siteSearchEntry{}
+	permalinks := map[string]string{} // quote ID to the episode it was found in
+	for _, season := range seasons {
+		err = writeSitePage(season.URL, "season.html", "../", season.Name, season)
+		if err != nil {
+			return err
+		}
+
+		for _, ep := range season.Episodes {
+			err = writeSitePage(ep.URL, "episode.html", "../", ep.Title, ep)
+			if err != nil {
+				return err
+			}
+
+			for _, q := range ep.Quotes {
+				if previous, ok := permalinks[q.ID]; ok {
+					return errors.New("Quote " + q.ID + " was found in both " + previous + " and " + ep.Title + ", its permalink would be overwritten.")
+				}
+				permalinks[q.ID] = ep.Title
+				err = writeSitePage(q.URL, "quote.html", "../", q.ID, q)
+				if err != nil {
+					return err
+				}
+				index = append(index, newSiteSearchEntry(q))
+			}
+		}
+	}
+
+	for _, c := range characters {
+		err = writeSitePage(c.URL, "character.html", "../", c.Name, c)
+		if err != nil {
+			return err
+		}
+	}
+
+	// the index is a script rather than JSON, so searching also works when opening the files directly
+	data, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(filepath.Join(SiteDir, "search-index.js"), []byte("var searchIndex = "+string(data)+";\n"), 0644)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Built %d quotes from %d episodes into %s.\n", len(index), getSiteEpisodeCount(seasons), SiteDir)
+	return nil
+}
+
+// getSiteContent retrieves every quote (and plot) of the series, organized by season, episode and character
+func getSiteContent() ([]*siteSeason, []*siteCharacter, error) {
+	characters := []*siteCharacter{}
+	for _, name := range getSupportedCharacters() {
+		characters = append(characters, &siteCharacter{Name: name, URL: "characters/" + getSlug(name) + ".html"})
+	}
+
+	seasons := []*siteSeason{}
+	for i, season := range getSeries() {
+		s := &siteSeason{Number: i + 1, Name: season.name, URL: fmt.Sprintf("seasons/%d.html", i+1)}
+		var quoteSeason Season
+		var err error
+		if i+1 != 5 { // films are retrieved one page at a time below
+			quoteSeason, err = getSeasonQuotesByNumber(i + 1)
+			if err != nil {
+				warnSkippedPage(getSeasonURL(i+1), err)
+			}
+		}
+
+		for x, name := range season.episodes {
+			if i+1 == 5 {
+				quoteSeason, err = getFilmQuotes(name)
+				if err != nil {
+					warnSkippedPage(getFilmURL(name), err)
+				}
+			}
+			ep := &siteEpisode{
+				Season: i + 1,
+				Number: x + 1,
+				Title:  name,
+				URL:    fmt.Sprintf("episodes/s%02de%02d-%s.html", i+1, x+1, getSlug(name)),
+			}
+			for _, e := range quoteSeason.episodes {
+				if !matchesEpisode(e.name, name) {
+					continue
+				}
+				for _, q := range getEpisodePool(e) {
+					sq := siteQuote{
+						QuoteDocument: newQuoteDocument(q),
+						URL:           "quotes/" + q.id + ".html",
+						EpisodeURL:    ep.URL + "#" + q.id,
+					}
+					ep.Quotes = append(ep.Quotes, sq)
+					ep.Source = sq.Source
+					for _, c := range characters {
+						if hasCharacter(q, c.Name) {
+							c.Quotes = append(c.Quotes, sq)
+						}
+					}
+				}
+			}
+			if !SiteNoPlots {
+				title := getWikipediaTitle(name)
+				ep.Plot, err = getEpisodePlot(title)
+				if err != nil { // the episode is listed without a plot
+					warnSkippedPlot(wikipedia.pageURL(title), err)
+				}
+				ep.PlotSource = wikipedia.pageURL(title)
+			}
+			s.Episodes = append(s.Episodes, ep)
+		}
+		seasons = append(seasons, s)
+	}
+
+	return seasons, characters, nil
+}
+
+// warnSkippedPage reports a WikiQuote page that couldn't be retrieved, whose episodes are built without quotes
+func warnSkippedPage(url string, err error) {
+	fmt.Fprintf(os.Stderr, "Warning: skipping the quotes of %s: %v\n", url, err)
+}
+
+// warnSkippedPlot reports an episode whose plot couldn't be retrieved from Wikipedia, which is built without one
+func warnSkippedPlot(url string, err error) {
+	fmt.Fprintf(os.Stderr, "Warning: skipping the plot of %s: %v\n", url, err)
+}
+
+func getSiteEpisodeCount(seasons []*siteSeason) int {
+	count := 0
+	for _, s := range seasons {
+		count += len(s.Episodes)
+	}
+
+	return count
+}
+
+func newSiteSearchEntry(q siteQuote) siteSearchEntry {
+	text := []string{}
+	for _, line := range q.Lines {
+		text = append(text, line.Text)
+	}
+
+	return siteSearchEntry{
+		ID:       q.ID,
+		Episode:  q.Episode.Title,
+		Speakers: strings.Join(q.Speakers, ", "),
+		Text:     strings.Join(text, " "),
+		URL:      q.URL,
+	}
+}
+
+// parsed page templates, by page. They are cloned for each page, which gets its own "link" function.
+var siteTemplates = map[string]*template.Template{}
+
+// writeSitePage renders a page template inside the layout, to path in the site directory.
+// Links are made relative to the page with the "link" template function, using root (e.g. "../").
+func writeSitePage(path string, page string, root string, title string, content any) error {
+	t, ok := siteTemplates[page]
+	if !ok {
+		funcs := template.FuncMap{
+			"slug": getSlug,
+			"join": func(sep string, list []string) string { return strings.Join(list, sep) },
+			"link": func(target string) string { return target },
+		}
+		var err error
+		t, err = template.New("layout.html").Funcs(funcs).ParseFS(siteFiles, "site/layout.html", "site/quoteLines.html", "site/"+page)
+		if err != nil {
+			return err
+		}
+		siteTemplates[page] = t
+	}
+
+	t, err := t.Clone()
+	if err != nil {
+		return err
+	}
+	t.Funcs(template.FuncMap{"link": func(target string) string { return root + target }})
+
+	var b bytes.Buffer
+	err = t.Execute(&b, sitePage{Title: title, Content: content})
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(SiteDir, path), b.Bytes(), 0644)
+}
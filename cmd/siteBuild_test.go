@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteSitePageLinks(t *testing.T) {
+	dir := SiteDir
+	SiteDir = t.TempDir()
+	t.Cleanup(func() { SiteDir = dir })
+	if err := os.Mkdir(filepath.Join(SiteDir, "seasons"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	season := &siteSeason{Number: 1, Name: "Season 1", URL: "seasons/1.html"}
+	// the same template at two depths, each page links relative to itself
+	for _, page := range []struct{ path, root string }{{"season.html", ""}, {"seasons/1.html", "../"}, {"top.html", ""}} {
+		err := writeSitePage(page.path, "season.html", page.root, season.Name, season)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := os.ReadFile(filepath.Join(SiteDir, page.path))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := `href="` + page.root + `style.css"`; !strings.Contains(string(data), want) {
+			t.Errorf("%s: no %s link", page.path, want)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+-- Schema of the SQLite corpus written by 'futurama export sqlite' (see docs/sqlite.md).
+-- The schema version is stored in PRAGMA user_version.
+
+CREATE TABLE metadata (
+    key   TEXT PRIMARY KEY,
+    value TEXT NOT NULL
+);
+
+CREATE TABLE seasons (
+    number INTEGER PRIMARY KEY,
+    name   TEXT NOT NULL
+);
+
+CREATE TABLE episodes (
+    id              INTEGER PRIMARY KEY,
+    season          INTEGER NOT NULL REFERENCES seasons (number),
+    number          INTEGER,          -- position in the season, NULL if the episode is unknown to futurama
+    title           TEXT NOT NULL,
+    wikiquote_title TEXT NOT NULL,    -- title used on WikiQuote, which can differ from title
+    source          TEXT NOT NULL,    -- WikiQuote page the quotes were parsed from
+    UNIQUE (season, wikiquote_title)
+);
+
+CREATE TABLE characters (
+    id   INTEGER PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE         -- normalized name, e.g. 'Prof. Farnsworth'
+);
+
+CREATE TABLE character_aliases (
+    alias        TEXT PRIMARY KEY,    -- name as written on WikiQuote, e.g. 'Professor Farnsworth'
+    character_id INTEGER NOT NULL REFERENCES characters (id)
+);
+
+CREATE TABLE quotes (
+    id         TEXT PRIMARY KEY,      -- stable quote ID, e.g. 's01e01-1a2b3c4d'
+    episode_id INTEGER NOT NULL REFERENCES episodes (id),
+    position   INTEGER NOT NULL       -- order of the quote on the episode's page
+);
+
+CREATE TABLE quote_characters (
+    quote_id     TEXT NOT NULL REFERENCES quotes (id),
+    character_id INTEGER NOT NULL REFERENCES characters (id), -- every character speaking in the quote, e.g. both in 'Fry and Leela:'
+    PRIMARY KEY (quote_id, character_id)
+);
+
+CREATE TABLE lines (
+    quote_id     TEXT NOT NULL REFERENCES quotes (id),
+    position     INTEGER NOT NULL,    -- order of the line in the quote
+    character_id INTEGER REFERENCES characters (id), -- NULL for stage directions
+    speaker      TEXT,                -- speaker's name as written, NULL for stage directions
+    text         TEXT NOT NULL,       -- the line without the speaker's name
+    raw          TEXT NOT NULL,       -- the line as written on WikiQuote
+    PRIMARY KEY (quote_id, position)
+);
+
+CREATE TABLE plots (
+    episode_id INTEGER NOT NULL REFERENCES episodes (id),
+    position   INTEGER NOT NULL,      -- order of the paragraph
+    text       TEXT NOT NULL,
+    source     TEXT NOT NULL,         -- Wikipedia page the plot was parsed from
+    PRIMARY KEY (episode_id, position)
+);
+
+-- full-text search of quotes, e.g. SELECT quote_id FROM quotes_fts WHERE quotes_fts MATCH 'shiny metal'
+CREATE VIRTUAL TABLE quotes_fts USING fts5 (
+    quote_id UNINDEXED,
+    speakers,
+    text
+);
+
+CREATE INDEX episodes_season ON episodes (season, number);
+CREATE INDEX quotes_episode ON quotes (episode_id, position);
+CREATE INDEX lines_character ON lines (character_id);
+CREATE INDEX quote_characters_character ON quote_characters (character_id);
@@ -0,0 +1,62 @@
+/*
+Copyright © 2023 Aric Hansen <aric.p.hansen@gmail.com>
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// getDataPath returns the path of a file in the futurama config directory (e.g. ~/.config/futurama on Linux)
+func getDataPath(name string) (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "futurama", name), nil
+}
+
+// readJSONFile decodes a JSON file into v. A missing file is not an error; v is left unchanged.
+func readJSONFile(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(data, v)
+	if err != nil {
+		return errors.New("Error reading " + path + ": " + err.Error())
+	}
+
+	return nil
+}
+
+// writeJSONFile encodes v into a JSON file, creating its directory if needed.
+// The file is written to a temporary path first so a failed write never leaves it half-written.
+func writeJSONFile(path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	err = os.WriteFile(tmp, append(data, '\n'), 0o644)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
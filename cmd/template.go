@@ -0,0 +1,116 @@
+/*
+Copyright © 2023 Aric Hansen <aric.p.hansen@gmail.com>
+*/
+package cmd
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/pflag"
+)
+
+var OutputTemplate string
+var TemplateFile string
+
+// parsed --format or --template-file template, set by validateTemplate
+var outputTemplate *template.Template
+
+// addTemplateFlags registers --format and --template-file on commands that support templated output
+func addTemplateFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&OutputTemplate, "format", "", "Go text/template used to print each result (see docs/templates.md)")
+	flags.StringVar(&TemplateFile, "template-file", "", "Path of a Go text/template file used to print each result")
+}
+
+// validateTemplate parses the --format or --template-file template so errors are reported before any work is done
+func validateTemplate() error {
+	if OutputTemplate == "" && TemplateFile == "" {
+		return nil
+	}
+
+	if OutputTemplate != "" && TemplateFile != "" {
+		return errors.New("The --format and --template-file flags cannot be used together.")
+	}
+	if OutputFormat != outputText {
+		return errors.New("The --format and --template-file flags cannot be used with --output.")
+	}
+
+	text := OutputTemplate
+	if TemplateFile != "" {
+		data, err := os.ReadFile(TemplateFile)
+		if err != nil {
+			return errors.New("Unable to read template file " + TemplateFile + ".")
+		}
+		text = string(data)
+	}
+
+	var err error
+	outputTemplate, err = template.New("format").Funcs(getTemplateFuncs()).Parse(text)
+	if err != nil {
+		return errors.New("Invalid template: " + err.Error())
+	}
+
+	return nil
+}
+
+func isTemplateOutput() bool {
+	return outputTemplate != nil
+}
+
+// writeTemplate executes the template once per item, ending each result with a newline
+func writeTemplate(items []any) error {
+	for _, item := range items {
+		var b strings.Builder
+		err := outputTemplate.Execute(&b, item)
+		if err != nil {
+			return errors.New("Error executing template: " + err.Error())
+		}
+
+		result := b.String()
+		if !strings.HasSuffix(result, "\n") {
+			result += "\n"
+		}
+		os.Stdout.WriteString(result)
+	}
+
+	return nil
+}
+
+// getTemplateFuncs returns the helpers available to templates, in addition to the text/template builtins
+func getTemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"wrap":  wordWrap,
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+		"color": colorize,
+		"join": func(sep string, list []string) string {
+			return strings.Join(list, sep)
+		},
+	}
+}
+
+// QuoteTemplateData is the data passed to 'get quote' templates
+type QuoteTemplateData struct {
+	QuoteDocument
+	Speaker string // every speaker, joined with ", "
+	Text    string // the lines, without speaker names if there is a single speaker
+}
+
+func newQuoteTemplateData(doc QuoteDocument) QuoteTemplateData {
+	lines := []string{}
+	for _, line := range doc.Lines {
+		if len(doc.Speakers) > 1 && line.Speaker != "" {
+			lines = append(lines, strings.Join(line.Speakers, " & ")+": "+line.Text)
+		} else {
+			lines = append(lines, line.Text)
+		}
+	}
+
+	return QuoteTemplateData{
+		QuoteDocument: doc,
+		Speaker:       strings.Join(doc.Speakers, ", "),
+		Text:          strings.Join(lines, "\n"),
+	}
+}
@@ -20,7 +20,14 @@ var versionCmd = &cobra.Command{
 	Long:    "Display the version for Futurama CLI.",
 	Example: `  futurama version`,
 	Run: func(cmd *cobra.Command, args []string) {
-		printVersion()
+		if isStructuredOutput() {
+			err := writeDocument(VersionDocument{Version: Version}, []any{VersionDocument{Version: Version}})
+			if err != nil {
+				fmt.Println(err)
+			}
+		} else {
+			printVersion()
+		}
 	},
 }
 
@@ -0,0 +1,81 @@
+/*
+Copyright © 2023 Aric Hansen <aric.p.hansen@gmail.com>
+*/
+package cmd
+
+import (
+	"errors"
+	"os"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+var OutputWidth int
+
+// width that text output is wrapped to for this run (0 = no wrapping), set by validateWidth
+var wrapWidth int
+
+// validateWidth picks the wrapping width: --width if provided, otherwise the terminal width
+func validateWidth() error {
+	if OutputWidth < 0 {
+		return errors.New("Invalid --width value. Please select a value of 0 or more.")
+	}
+
+	wrapWidth = OutputWidth
+	if OutputWidth == 0 && term.IsTerminal(int(os.Stdout.Fd())) {
+		width, _, err := term.GetSize(int(os.Stdout.Fd()))
+		if err == nil {
+			wrapWidth = width
+		}
+	}
+
+	return nil
+}
+
+// ansiEscape matches the color codes added by colorize
+var ansiEscape = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// visibleLength counts the characters of text that take up space in a terminal
+func visibleLength(text string) int {
+	return utf8.RuneCountInString(ansiEscape.ReplaceAllString(text, ""))
+}
+
+// wordWrap breaks text into lines of at most width characters, only breaking between words.
+// Existing line breaks are kept, and words longer than width are left on a line of their own.
+func wordWrap(width int, text string) string {
+	return wrapIndent(text, width, 0)
+}
+
+// wrapIndent wraps text like wordWrap, indenting every line after the first by indent spaces
+// (e.g. to line up a quote's continuation lines after the speaker's name)
+func wrapIndent(text string, width int, indent int) string {
+	if width <= 0 {
+		return text
+	}
+	if indent > width/2 {
+		indent = width / 2
+	}
+	padding := strings.Repeat(" ", indent)
+
+	wrapped := []string{}
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			// a word longer than the line stays on the current line, even an indented one
+			if line != "" && line != padding && visibleLength(line)+1+visibleLength(word) > width {
+				wrapped = append(wrapped, line)
+				line = padding
+			}
+			if line != "" && line != padding {
+				line += " "
+			}
+			line += word
+		}
+		wrapped = append(wrapped, line)
+	}
+
+	return strings.Join(wrapped, "\n")
+}
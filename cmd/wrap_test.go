@@ -0,0 +1,29 @@
+package cmd
+
+import "testing"
+
+func TestWrapIndent(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		width  int
+		indent int
+		want   string
+	}{
+		{"no wrapping", "Fry: Yes.", 0, 5, "Fry: Yes."},
+		{"fits", "Fry: Yes.", 20, 5, "Fry: Yes."},
+		{"indented", "Fry: I'm gonna be a delivery boy.", 16, 5, "Fry: I'm gonna\n     be a\n     delivery\n     boy."},
+		{"line breaks kept", "Fry: Yes.\nLeela: No.", 20, 5, "Fry: Yes.\nLeela: No."},
+		{"long first word", "Supercalifragilistic is long", 10, 0, "Supercalifragilistic\nis long"},
+		{"long word after the first", "Fry: Supercalifragilistic", 10, 5, "Fry:\n     Supercalifragilistic"},
+		{"long words in a row", "Fry: Supercalifragilistic Expialidocious", 10, 5, "Fry:\n     Supercalifragilistic\n     Expialidocious"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wrapIndent(tt.text, tt.width, tt.indent); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}